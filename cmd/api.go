@@ -4,12 +4,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/reflective-technologies/kiosk-cli/internal/api"
+	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
 	"github.com/reflective-technologies/kiosk-cli/internal/auth"
 	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	"github.com/reflective-technologies/kiosk-cli/internal/giturl"
+	"github.com/reflective-technologies/kiosk-cli/internal/kioskmd"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var apiCmd = &cobra.Command{
@@ -21,17 +28,38 @@ var apiCmd = &cobra.Command{
 var apiListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all published apps",
+	Long: `List all published apps as JSON.
+
+--since accepts a date (2024-01-31, 2024/01/31, Jan 31 2024, RFC 3339) or a
+duration back from now (36h, 7d, 2w). An app matches if it was created or
+updated at or after that time; apps without timestamps are left out.
+
+--creator limits the list to apps published by a GitHub username.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		var since time.Time
+		if s, _ := cmd.Flags().GetString("since"); s != "" {
+			var err error
+			if since, err = api.ParseSince(s); err != nil {
+				return fmt.Errorf("invalid --since: %w", err)
+			}
+		}
+
 		cfg, err := config.Load()
 		if err != nil {
 			return err
 		}
 
-		client := api.NewClient(cfg.APIUrl)
-		apps, err := client.ListApps()
+		client := newAPIClient(cfg)
+		var apps []api.App
+		if creator, _ := cmd.Flags().GetString("creator"); creator != "" {
+			apps, err = client.ListAppsByCreator(creator)
+		} else {
+			apps, err = client.ListApps()
+		}
 		if err != nil {
 			return err
 		}
+		apps = api.FilterSince(apps, since)
 
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
@@ -49,8 +77,11 @@ var apiGetCmd = &cobra.Command{
 			return err
 		}
 
-		client := api.NewClient(cfg.APIUrl)
-		app, err := client.GetApp(args[0])
+		client := newAPIClient(cfg)
+		refresh, _ := cmd.Flags().GetBool("refresh")
+		app, err := cachedAppFetch(cfg, "app", args[0], refresh, func() (*api.App, error) {
+			return client.GetApp(args[0])
+		})
 		if err != nil {
 			return err
 		}
@@ -64,17 +95,49 @@ var apiGetCmd = &cobra.Command{
 var apiCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Publish a new app",
+	Long: `Publish a new app from a JSON CreateAppRequest read from -f or stdin.
+
+With --from-repo, the request is built from the git repository in the current
+directory instead: the origin remote's URL, the current branch, the
+subdirectory holding the app's KIOSK.md, and the name and description the
+KIOSK.md gives. --name, --description, --git-url, --branch and --subdirectory
+override individual fields in either mode.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Check authentication
-		token, err := auth.GetToken()
+		token, err := apiToken()
 		if err != nil {
-			return fmt.Errorf("not logged in, run 'kiosk login' first")
+			return err
 		}
 
-		inputFile, _ := cmd.Flags().GetString("file")
 		var req api.CreateAppRequest
-		if err := readJSONInput(inputFile, &req); err != nil {
-			return err
+		if fromRepo, _ := cmd.Flags().GetBool("from-repo"); fromRepo {
+			branch, _ := cmd.Flags().GetString("branch")
+			subdir, _ := cmd.Flags().GetString("subdirectory")
+			if req, err = createRequestFromRepo(branch, subdir); err != nil {
+				return err
+			}
+		} else {
+			inputFile, _ := cmd.Flags().GetString("file")
+			if err := readJSONInput(inputFile, &req); err != nil {
+				return err
+			}
+		}
+		for flag, field := range map[string]*string{
+			"name":         &req.Name,
+			"description":  &req.Description,
+			"git-url":      &req.GitUrl,
+			"branch":       &req.Branch,
+			"subdirectory": &req.Subdirectory,
+		} {
+			if value, _ := cmd.Flags().GetString(flag); value != "" {
+				*field = value
+			}
+		}
+		if req.Name == "" {
+			return fmt.Errorf("app name is required: give the KIOSK.md a title or use --name")
+		}
+		if req.GitUrl == "" {
+			return fmt.Errorf("git URL is required: add an origin remote or use --git-url")
 		}
 
 		cfg, err := config.Load()
@@ -97,12 +160,21 @@ var apiCreateCmd = &cobra.Command{
 var apiUpdateCmd = &cobra.Command{
 	Use:   "update [appId]",
 	Short: "Update an existing app",
-	Args:  cobra.ExactArgs(1),
+	Long: `Update an existing app from a JSON UpdateAppRequest read from -f or stdin.
+
+Updates merge: only the fields present and non-empty in the request change,
+and everything else keeps its current value, so a partial request never
+clears a field. An empty string can't be used to clear one either.
+
+The app is fetched first and the fields that would change are shown with
+their current and new values. On a terminal you are asked to confirm;
+--yes skips the prompt. If nothing would change, no update is sent.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Check authentication
-		token, err := auth.GetToken()
+		token, err := apiToken()
 		if err != nil {
-			return fmt.Errorf("not logged in, run 'kiosk login' first")
+			return err
 		}
 
 		inputFile, _ := cmd.Flags().GetString("file")
@@ -117,26 +189,105 @@ var apiUpdateCmd = &cobra.Command{
 		}
 
 		client := api.NewAuthenticatedClient(cfg.APIUrl, token)
-		app, err := client.UpdateApp(args[0], req)
+		current, err := client.GetApp(args[0])
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to fetch the app to update: %w", err)
 		}
 
+		changes := appChanges(current, req)
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
+		if len(changes) == 0 {
+			fmt.Fprintln(os.Stderr, "Nothing to update: the request matches the app as it is.")
+			return enc.Encode(current)
+		}
+
+		fmt.Fprintf(os.Stderr, "Changes to %s:\n", current.ID)
+		for _, c := range changes {
+			fmt.Fprintf(os.Stderr, "  %s\n", c.field)
+			fmt.Fprintf(os.Stderr, "    - %s\n", c.before)
+			fmt.Fprintf(os.Stderr, "    + %s\n", c.after)
+		}
+		if !assumeYes && term.IsTerminal(int(os.Stdin.Fd())) {
+			ok, err := confirm("Apply these changes?")
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("update cancelled; %s was left as is", current.ID)
+			}
+		}
+
+		app, err := client.UpdateApp(args[0], req)
+		if err != nil {
+			return err
+		}
+
 		return enc.Encode(app)
 	},
 }
 
+// appChange is one field an update would change
+type appChange struct {
+	field, before, after string
+}
+
+// appChanges lists the fields req would change on app. Fields the API
+// doesn't return can't be compared, so any value given for them is listed
+// with an unknown current value.
+func appChanges(app *api.App, req api.UpdateAppRequest) []appChange {
+	var changes []appChange
+	for _, f := range []struct {
+		field   string
+		current *string // nil if the API doesn't return it
+		value   string
+	}{
+		{"name", &app.Name, req.Name},
+		{"description", &app.Description, req.Description},
+		{"gitUrl", &app.GitUrl, req.GitUrl},
+		{"branch", &app.Branch, req.Branch},
+		{"subdirectory", nil, req.Subdirectory},
+		{"screenshot", nil, req.Screenshot},
+		{"instructions", nil, req.Instructions},
+		{"howItWorks", &app.HowItWorks, req.HowItWorks},
+	} {
+		// Omitted fields keep their value
+		if f.value == "" {
+			continue
+		}
+		before := "(current value not available)"
+		if f.current != nil {
+			if *f.current == f.value {
+				continue
+			}
+			before = diffValue(*f.current)
+		}
+		changes = append(changes, appChange{f.field, before, diffValue(f.value)})
+	}
+	return changes
+}
+
+// diffValue shows a field value on one line, cut short if it's long
+func diffValue(s string) string {
+	if s == "" {
+		return "(empty)"
+	}
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > 100 {
+		return string(r[:97]) + "..."
+	}
+	return s
+}
+
 var apiDeleteCmd = &cobra.Command{
 	Use:   "delete [appId]",
 	Short: "Delete an app",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Check authentication
-		token, err := auth.GetToken()
+		token, err := apiToken()
 		if err != nil {
-			return fmt.Errorf("not logged in, run 'kiosk login' first")
+			return err
 		}
 
 		cfg, err := config.Load()
@@ -160,9 +311,9 @@ var apiRefreshCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Check authentication
-		token, err := auth.GetToken()
+		token, err := apiToken()
 		if err != nil {
-			return fmt.Errorf("not logged in, run 'kiosk login' first")
+			return err
 		}
 
 		cfg, err := config.Load()
@@ -170,7 +321,13 @@ var apiRefreshCmd = &cobra.Command{
 			return err
 		}
 
-		client := api.NewAuthenticatedClient(cfg.APIUrl, token)
+		// An installed app is refreshed on the API it was installed from,
+		// which the token, issued for the configured API, isn't sent to
+		apiURL, _ := appindex.Origin(cfg, normalizeAppKey(args[0]))
+		if api.NormalizeBaseURL(apiURL) != api.NormalizeBaseURL(cfg.APIUrl) {
+			return fmt.Errorf("%s was installed from %s, but you're logged in to %s; set apiUrl to %s and log in there to refresh it", args[0], apiURL, cfg.APIUrl, apiURL)
+		}
+		client := api.NewAuthenticatedClient(apiURL, token)
 		if err := client.RefreshApp(args[0]); err != nil {
 			return err
 		}
@@ -189,7 +346,7 @@ var apiInitPromptCmd = &cobra.Command{
 			return err
 		}
 
-		client := api.NewClient(cfg.APIUrl)
+		client := newAPIClient(cfg)
 		prompt, err := client.GetInitPrompt()
 		if err != nil {
 			return err
@@ -209,7 +366,7 @@ var apiPublishPromptCmd = &cobra.Command{
 			return err
 		}
 
-		client := api.NewClient(cfg.APIUrl)
+		client := newAPIClient(cfg)
 		prompt, err := client.GetPublishPrompt()
 		if err != nil {
 			return err
@@ -220,6 +377,107 @@ var apiPublishPromptCmd = &cobra.Command{
 	},
 }
 
+var apiWhoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show the authenticated user as the server sees it",
+	Long: `Print the user the API token belongs to, as JSON.
+
+Unlike 'kiosk whoami', which reads the locally stored credentials, this asks
+the server, so it fails if the token has expired or been revoked.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := apiToken()
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		client := api.NewAuthenticatedClient(cfg.APIUrl, token)
+		user, err := client.GetCurrentUser()
+		if err != nil {
+			return err
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(user)
+	},
+}
+
+// apiTokenFlag overrides stored credentials, for CI where 'kiosk login' isn't possible
+var apiTokenFlag string
+
+// apiToken returns the --token flag if set, otherwise the stored login token
+func apiToken() (string, error) {
+	if apiTokenFlag != "" {
+		return apiTokenFlag, nil
+	}
+	token, err := auth.GetToken()
+	if err != nil {
+		return "", fmt.Errorf("not logged in, run 'kiosk login' first or pass --token")
+	}
+	return token, nil
+}
+
+// newAPIClient returns a client for read endpoints. It authenticates with
+// --token or stored credentials when available and falls back to anonymous.
+func newAPIClient(cfg *config.Config) *api.Client {
+	if apiTokenFlag != "" {
+		return api.NewAuthenticatedClient(cfg.APIUrl, apiTokenFlag).WithRegistry(cfg.Registry)
+	}
+	return api.NewClientFromCreds(cfg.APIUrl).WithRegistry(cfg.Registry)
+}
+
+// createRequestFromRepo builds a create request for the app in the current
+// directory's repository, finding it the same way kiosk publish does
+func createRequestFromRepo(branch, subdir string) (api.CreateAppRequest, error) {
+	cwd, err := resolveWorkDir("")
+	if err != nil {
+		return api.CreateAppRequest{}, err
+	}
+	if err := requireGit("read the repository"); err != nil {
+		return api.CreateAppRequest{}, err
+	}
+	target, err := resolvePublishTarget(cwd, branch, subdir)
+	if err != nil {
+		return api.CreateAppRequest{}, err
+	}
+	info, err := kioskmd.ReadInfo(target.appDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return api.CreateAppRequest{}, fmt.Errorf("no KIOSK.md found. Run 'kiosk init' first to create one")
+		}
+		return api.CreateAppRequest{}, fmt.Errorf("failed to read KIOSK.md: %w", err)
+	}
+
+	req := api.CreateAppRequest{
+		Name:         info.Name,
+		Description:  info.Description,
+		Branch:       target.branch,
+		Subdirectory: target.subdirectory,
+	}
+	if origin, err := originURL(cwd); err == nil {
+		req.GitUrl = publicGitURL(origin)
+	}
+	return req, nil
+}
+
+// publicGitURL returns the https form of an origin URL without any
+// credentials embedded in it, e.g. https://<token>@github.com/org/repo, so
+// they aren't sent to Kiosk as part of the app
+func publicGitURL(origin string) string {
+	gitURL := giturl.HTTPS(origin)
+	if u, err := url.Parse(gitURL); err == nil && u.User != nil {
+		u.User = nil
+		gitURL = u.String()
+	}
+	return gitURL
+}
+
 func readJSONInput(path string, v any) error {
 	var r io.Reader
 
@@ -259,7 +517,20 @@ func init() {
 	apiCmd.AddCommand(apiRefreshCmd)
 	apiCmd.AddCommand(apiInitPromptCmd)
 	apiCmd.AddCommand(apiPublishPromptCmd)
+	apiCmd.AddCommand(apiWhoamiCmd)
+
+	apiCmd.PersistentFlags().StringVar(&apiTokenFlag, "token", "", "API token to use instead of stored credentials")
 
+	apiListCmd.Flags().String("since", "", "Only list apps created or updated since a date or duration (e.g. 2024-01-31, 7d)")
+	apiListCmd.Flags().String("creator", "", "Only list apps published by this GitHub username")
+	apiGetCmd.Flags().Bool("refresh", false, "Fetch the app again instead of using a cached copy up to 10 minutes old")
 	apiCreateCmd.Flags().StringP("file", "f", "", "Path to JSON file (use - for stdin)")
+	apiCreateCmd.Flags().Bool("from-repo", false, "Build the request from the git repository in the current directory")
+	apiCreateCmd.Flags().String("name", "", "Set the app's name, overriding the input")
+	apiCreateCmd.Flags().String("description", "", "Set the app's description, overriding the input")
+	apiCreateCmd.Flags().String("git-url", "", "Set the app's git URL, overriding the input")
+	apiCreateCmd.Flags().String("branch", "", "Set the branch to publish, overriding the input")
+	apiCreateCmd.Flags().String("subdirectory", "", "Set the app's directory in the repo, overriding the input")
+	apiCreateCmd.MarkFlagsMutuallyExclusive("file", "from-repo")
 	apiUpdateCmd.Flags().StringP("file", "f", "", "Path to JSON file (use - for stdin)")
 }
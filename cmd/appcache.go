@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/cache"
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+)
+
+// appCacheTTL is how long fetched app metadata and install prompts are
+// reused before asking the API again
+const appCacheTTL = 10 * time.Minute
+
+// cachedAppFetch returns the kind of data cached for app id, calling fetch
+// and caching its result if nothing fresh is cached or refresh is set. Keys
+// include the API and registry, so switching either never serves another
+// server's data.
+func cachedAppFetch[T any](cfg *config.Config, kind, id string, refresh bool, fetch func() (T, error)) (T, error) {
+	store := cache.Default()
+	key := strings.Join([]string{kind, cfg.APIUrl, cfg.Registry, id}, "\n")
+
+	var v T
+	if !refresh && store.Get(key, appCacheTTL, &v) {
+		return v, nil
+	}
+
+	v, err := fetch()
+	if err != nil {
+		return v, err
+	}
+	_ = store.Put(key, v) // a cache that can't be written only costs a refetch
+	return v, nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
+)
+
+// latestVersion unpins an app when used as org/repo@latest
+const latestVersion = "latest"
+
+// splitAppVersion splits an app argument like org/repo@v1.2.0 into the app
+// and the requested version. version is empty when none was given.
+func splitAppVersion(input string) (app, version string) {
+	if i := strings.LastIndex(input, "@"); i > 0 {
+		return input[:i], input[i+1:]
+	}
+	return input, ""
+}
+
+// appPinnedVersion returns the git tag an installed app is pinned to, or ""
+func appPinnedVersion(key string) string {
+	idx, err := appindex.Load()
+	if err != nil {
+		return ""
+	}
+	if entry := idx.Get(key); entry != nil {
+		return entry.Version
+	}
+	return ""
+}
+
+// setAppVersion pins an installed app to the given git tag, or unpins it and
+// returns it to its default branch when version is "latest".
+func setAppVersion(idx *appindex.Index, key, version string) error {
+	entry := idx.Get(key)
+	if entry == nil {
+		return fmt.Errorf("app %s is not installed", key)
+	}
+
+	appPath := appindex.Path(key)
+	if version == latestVersion {
+		if entry.Version == "" {
+			return nil
+		}
+		if err := checkoutDefaultBranch(appPath); err != nil {
+			return err
+		}
+		entry.Version = ""
+		infof("Unpinned %s; it will update automatically again\n", key)
+	} else {
+		if entry.Version == version {
+			return nil
+		}
+		if err := checkoutVersion(appPath, version); err != nil {
+			return err
+		}
+		entry.Version = version
+		infof("Pinned %s to %s\n", key, version)
+	}
+
+	if err := appindex.Save(idx); err != nil {
+		return fmt.Errorf("failed to save app index: %w", err)
+	}
+	return nil
+}
+
+// checkoutVersion fetches tags and checks out the given tag in dir
+func checkoutVersion(dir, version string) error {
+	if err := gitRun(dir, "fetch", "--quiet", "--tags"); err != nil {
+		return fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	ref := "refs/tags/" + version
+	if _, err := gitOutput(dir, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		return fmt.Errorf("no version %s: the repository has no tag by that name", version)
+	}
+
+	if err := gitRun(dir, "checkout", "--quiet", ref); err != nil {
+		return fmt.Errorf("failed to check out %s: %w", version, err)
+	}
+	return nil
+}
+
+// checkoutDefaultBranch switches dir back to the remote's default branch
+// after it was pinned to a tag
+func checkoutDefaultBranch(dir string) error {
+	head, err := gitOutput(dir, "rev-parse", "--abbrev-ref", "origin/HEAD")
+	if err != nil {
+		return fmt.Errorf("failed to determine default branch: %w", err)
+	}
+
+	branch := strings.TrimPrefix(head, "origin/")
+	if err := gitRun(dir, "checkout", "--quiet", branch); err != nil {
+		return fmt.Errorf("failed to check out %s: %w", branch, err)
+	}
+	return nil
+}
@@ -2,19 +2,41 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
-	"time"
+	"slices"
+	"strings"
 
 	"github.com/charmbracelet/glamour"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/reflective-technologies/kiosk-cli/internal/clistyle"
 	kioskexec "github.com/reflective-technologies/kiosk-cli/internal/exec"
-	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
 
+var (
+	auditCwdFlag    string
+	auditFormatFlag string
+)
+
+// auditSeverities are the severities a structured finding may have
+var auditSeverities = []string{"critical", "warning", "info"}
+
+// auditFinding is one issue in a --format json audit
+type auditFinding struct {
+	File           string `json:"file"`
+	Line           int    `json:"line,omitempty"` // 1-based; 0 when not tied to a line
+	Severity       string `json:"severity"`
+	Type           string `json:"type"`
+	Recommendation string `json:"recommendation"`
+}
+
+// auditReport is the result of a --format json audit
+type auditReport struct {
+	Findings []auditFinding `json:"findings"`
+}
+
 var auditCmd = &cobra.Command{
 	Use:   "audit",
 	Short: "Audit the current directory for security issues before publishing",
@@ -23,15 +45,32 @@ var auditCmd = &cobra.Command{
 - Personal information that shouldn't be published
 - Git history containing previously committed secrets
 
-This command runs Claude with an audit-focused prompt and prints the results.`,
+This command runs Claude with an audit-focused prompt and prints the results.
+Use --cwd to audit another directory.
+
+With --format json the findings are printed as a JSON object for other
+tools: {"findings": [{"file", "line", "severity", "type", "recommendation"}]}.`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cwd, err := os.Getwd()
+		cwd, err := resolveWorkDir(auditCwdFlag)
 		if err != nil {
-			return fmt.Errorf("failed to get current directory: %w", err)
+			return err
 		}
 
-		return execClaudeAudit(cwd, kioskexec.AuditPrompt)
+		switch auditFormatFlag {
+		case "markdown":
+			return execClaudeAudit(cwd, kioskexec.AuditPrompt(cwd))
+		case "json":
+			report, err := execClaudeAuditJSON(cwd)
+			if err != nil {
+				return err
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(report)
+		default:
+			return fmt.Errorf("invalid --format %q (expected markdown or json)", auditFormatFlag)
+		}
 	},
 }
 
@@ -43,40 +82,8 @@ func execClaudeAudit(dir, prompt string) error {
 	cmd.Stdout = &stdout
 	cmd.Stderr = os.Stderr
 
-	done := make(chan error, 1)
-	go func() {
-		done <- cmd.Run()
-	}()
-
-	// Styled spinner
-	spinnerStyle := lipgloss.NewStyle().Foreground(styles.Primary)
-	textStyle := lipgloss.NewStyle().Foreground(styles.Muted)
-
-	if term.IsTerminal(int(os.Stdout.Fd())) {
-		frames := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
-		i := 0
-		ticker := time.NewTicker(80 * time.Millisecond)
-		defer ticker.Stop()
-
-		fmt.Print(spinnerStyle.Render(frames[0]) + " " + textStyle.Render("Running security audit..."))
-	loop:
-		for {
-			select {
-			case err := <-done:
-				fmt.Print("\r\033[K") // Clear line
-				if err != nil {
-					return err
-				}
-				break loop
-			case <-ticker.C:
-				i = (i + 1) % len(frames)
-				fmt.Print("\r" + spinnerStyle.Render(frames[i]) + " " + textStyle.Render("Running security audit..."))
-			}
-		}
-	} else {
-		if err := <-done; err != nil {
-			return err
-		}
+	if err := withSpinner("Running security audit...", cmd.Run); err != nil {
+		return err
 	}
 
 	output := stdout.String()
@@ -104,6 +111,86 @@ func execClaudeAudit(dir, prompt string) error {
 	return nil
 }
 
+// execClaudeAuditJSON runs the audit asking for structured findings and
+// returns them validated
+func execClaudeAuditJSON(dir string) (*auditReport, error) {
+	cmd := kioskexec.ClaudeCmd("-p", kioskexec.AuditJSONPrompt(dir))
+	cmd.Dir = dir
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	// Keep stdout clean for the JSON when it's piped
+	run := cmd.Run
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		run = func() error { return withSpinner("Running security audit...", cmd.Run) }
+	}
+	if err := run(); err != nil {
+		return nil, err
+	}
+
+	return parseAuditReport(stdout.String())
+}
+
+// parseAuditReport extracts and validates the JSON findings from Claude's
+// output, which may be wrapped in a markdown code fence or surrounded by
+// stray text
+func parseAuditReport(output string) (*auditReport, error) {
+	data := extractJSONObject(output)
+	if data == "" {
+		return nil, fmt.Errorf("audit output contained no JSON findings")
+	}
+
+	var report auditReport
+	if err := json.Unmarshal([]byte(data), &report); err != nil {
+		return nil, fmt.Errorf("failed to parse audit findings: %w", err)
+	}
+	if report.Findings == nil {
+		report.Findings = []auditFinding{}
+	}
+
+	for i := range report.Findings {
+		f := &report.Findings[i]
+		f.Severity = strings.ToLower(strings.TrimSpace(f.Severity))
+		if !slices.Contains(auditSeverities, f.Severity) {
+			return nil, fmt.Errorf("audit finding %d has invalid severity %q (expected %s)", i+1, f.Severity, strings.Join(auditSeverities, ", "))
+		}
+		if f.Line < 0 {
+			return nil, fmt.Errorf("audit finding %d has invalid line %d", i+1, f.Line)
+		}
+		if strings.TrimSpace(f.Type) == "" || strings.TrimSpace(f.Recommendation) == "" {
+			return nil, fmt.Errorf("audit finding %d is missing its type or recommendation", i+1)
+		}
+	}
+	return &report, nil
+}
+
+// extractJSONObject returns the JSON object in s: the contents of the first
+// fenced code block if there is one, otherwise everything from the first {
+// to the last }. It returns "" if there's no object.
+func extractJSONObject(s string) string {
+	if start := strings.Index(s, "```"); start >= 0 {
+		body := s[start+3:]
+		// Skip the info string, e.g. json
+		if nl := strings.IndexByte(body, '\n'); nl >= 0 {
+			body = body[nl+1:]
+			if end := strings.Index(body, "```"); end >= 0 {
+				s = body[:end]
+			}
+		}
+	}
+
+	start := strings.IndexByte(s, '{')
+	end := strings.LastIndexByte(s, '}')
+	if start < 0 || end < start {
+		return ""
+	}
+	return s[start : end+1]
+}
+
 func init() {
+	auditCmd.Flags().StringVar(&auditCwdFlag, "cwd", "", "directory to audit instead of the current directory")
+	auditCmd.Flags().StringVar(&auditFormatFlag, "format", "markdown", "output format: markdown or json")
 	rootCmd.AddCommand(auditCmd)
 }
@@ -0,0 +1,40 @@
+package cmd
+
+import "testing"
+
+func TestParseAuditReport(t *testing.T) {
+	finding := `{"file": "config.js", "line": 3, "severity": "Critical", "type": "secret", "recommendation": "Rotate the key"}`
+	tests := []struct {
+		name     string
+		output   string
+		want     int
+		wantErr  bool
+		severity string
+	}{
+		{name: "bare", output: `{"findings": [` + finding + `]}`, want: 1, severity: "critical"},
+		{name: "fenced", output: "Here you go:\n```json\n{\"findings\": [" + finding + "]}\n```\nDone.", want: 1, severity: "critical"},
+		{name: "surrounding text", output: "Results: {\"findings\": []} end", want: 0},
+		{name: "null findings", output: `{"findings": null}`, want: 0},
+		{name: "no json", output: "No issues found.", wantErr: true},
+		{name: "bad severity", output: `{"findings": [{"file": "a", "severity": "high", "type": "secret", "recommendation": "x"}]}`, wantErr: true},
+		{name: "missing recommendation", output: `{"findings": [{"file": "a", "severity": "info", "type": "secret"}]}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := parseAuditReport(tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAuditReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if report.Findings == nil || len(report.Findings) != tt.want {
+				t.Fatalf("got %d findings, want %d", len(report.Findings), tt.want)
+			}
+			if tt.want > 0 && report.Findings[0].Severity != tt.severity {
+				t.Errorf("Severity = %q, want %q", report.Findings[0].Severity, tt.severity)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/reflective-technologies/kiosk-cli/internal/api"
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// browsePageSize is the number of apps requested per page when listing
+const browsePageSize = 50
+
+var (
+	browseJSON  bool
+	browseLimit int
+	browseSince string
+)
+
+var browseCmd = &cobra.Command{
+	Use:   "browse",
+	Short: "List apps available on Kiosk",
+	Long: `List apps from the Kiosk marketplace without starting the interactive UI.
+
+Prints a table when stdout is a terminal and JSON otherwise. Use --json to
+force JSON output. Use --since to show only apps created or updated after a
+date (2024-01-31) or within a duration (7d, 2w).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		var since time.Time
+		if browseSince != "" {
+			if since, err = api.ParseSince(browseSince); err != nil {
+				return fmt.Errorf("invalid --since: %w", err)
+			}
+		}
+
+		apps, err := fetchApps(api.NewClient(cfg.APIUrl).WithRegistry(cfg.Registry), browseLimit, since)
+		if err != nil {
+			return err
+		}
+
+		if browseJSON || !term.IsTerminal(int(os.Stdout.Fd())) {
+			data, err := json.MarshalIndent(apps, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		printAppTable(apps)
+		return nil
+	},
+}
+
+// fetchApps follows pagination cursors until limit apps have been fetched
+// or there are no more pages. A limit of 0 fetches everything. A non-zero
+// since drops apps not created or updated since then.
+func fetchApps(client *api.Client, limit int, since time.Time) ([]api.App, error) {
+	apps := []api.App{}
+	cursor := ""
+	for {
+		pageSize := browsePageSize
+		if limit > 0 && limit-len(apps) < pageSize {
+			pageSize = limit - len(apps)
+		}
+
+		result, err := client.ListAppsPaginated(pageSize, cursor)
+		if err != nil {
+			return nil, err
+		}
+		apps = append(apps, api.FilterSince(result.Apps, since)...)
+
+		if limit > 0 && len(apps) >= limit {
+			return apps[:limit], nil
+		}
+		if result.NextCursor == nil || len(result.Apps) == 0 {
+			return apps, nil
+		}
+		cursor = *result.NextCursor
+	}
+}
+
+func printAppTable(apps []api.App) {
+	fmt.Println()
+	if len(apps) == 0 {
+		fmt.Println(styles.MutedStyle.Render("  No apps available yet."))
+		fmt.Println()
+		return
+	}
+
+	width := 80
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		width = w
+	}
+
+	nameStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+	installStyle := lipgloss.NewStyle().Foreground(styles.Secondary)
+	descStyle := styles.MutedStyle.Copy().MaxWidth(width - 4)
+
+	for _, app := range apps {
+		fmt.Print("  ")
+		fmt.Print(nameStyle.Render(app.Name))
+		if app.Creator != nil && app.Creator.Username != "" {
+			fmt.Print(styles.MutedStyle.Render(" by " + app.Creator.Username))
+		}
+		if app.InstallCount > 0 {
+			installText := "install"
+			if app.InstallCount != 1 {
+				installText = "installs"
+			}
+			fmt.Print("  ")
+			fmt.Print(installStyle.Render(fmt.Sprintf("%d %s", app.InstallCount, installText)))
+		}
+		fmt.Println()
+		if app.Description != "" {
+			fmt.Println("  " + descStyle.Render(app.Description))
+		}
+		fmt.Println(styles.MutedStyle.Render("  kiosk run " + app.ID))
+		fmt.Println()
+	}
+}
+
+func init() {
+	browseCmd.Flags().BoolVar(&browseJSON, "json", false, "print apps as JSON")
+	browseCmd.Flags().IntVarP(&browseLimit, "limit", "n", 50, "maximum number of apps to list (0 for all)")
+	browseCmd.Flags().StringVar(&browseSince, "since", "", "only list apps created or updated since a date or duration (e.g. 2024-01-31, 7d)")
+	rootCmd.AddCommand(browseCmd)
+}
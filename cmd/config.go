@@ -3,8 +3,17 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	kioskexec "github.com/reflective-technologies/kiosk-cli/internal/exec"
+	"github.com/reflective-technologies/kiosk-cli/internal/prefetch"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/components"
 	"github.com/spf13/cobra"
 )
 
@@ -28,7 +37,35 @@ var configGetCmd = &cobra.Command{
 		switch key {
 		case "apiUrl":
 			fmt.Println(cfg.APIUrl)
+		case "registry":
+			fmt.Println(cfg.Registry)
+		case "appsDir":
+			fmt.Println(config.AppsDir())
+		case "editor":
+			fmt.Println(cfg.Editor)
+		case "claudePath":
+			fmt.Println(cfg.ClaudePath)
+		case "telemetry.localLog":
+			fmt.Println(cfg.Telemetry.LocalLog)
+		case "browse.pageSize":
+			fmt.Println(cfg.Browse.PageSize)
+		case "git.timeout":
+			fmt.Println(cfg.Git.Timeout)
+		case "git.cloneDepth":
+			fmt.Println(cloneDepth())
+		case "updates.check":
+			fmt.Println(!cfg.Updates.DisableCheck)
+		case "ui.spinner":
+			fmt.Println(cfg.UI.Spinner)
+		case "ui.idleTimeout":
+			fmt.Println(cfg.UI.IdleTimeout)
+		case "updates.baseUrl":
+			fmt.Println(cfg.Updates.BaseURL)
 		default:
+			if action, ok := keyBindingAction(key); ok {
+				fmt.Println(strings.Join(cfg.KeyBindings[action], ","))
+				break
+			}
 			return fmt.Errorf("unknown config key: %s", key)
 		}
 
@@ -54,8 +91,102 @@ var configSetCmd = &cobra.Command{
 		switch key {
 		case "apiUrl":
 			cfg.APIUrl = value
+		case "registry":
+			cfg.Registry = value
+		case "appsDir":
+			if value != "" && !filepath.IsAbs(value) && !strings.HasPrefix(value, "~") {
+				return fmt.Errorf("appsDir must be an absolute path: %s", value)
+			}
+			cfg.AppsDir = value
+		case "editor":
+			cfg.Editor = value
+		case "claudePath":
+			if value != "" {
+				if !filepath.IsAbs(value) {
+					return fmt.Errorf("claudePath must be an absolute path: %s", value)
+				}
+				if err := kioskexec.CheckClaudePath(value); err != nil {
+					return err
+				}
+			}
+			cfg.ClaudePath = value
+		case "telemetry.localLog":
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value for %s: %q (expected true or false)", key, value)
+			}
+			cfg.Telemetry.LocalLog = enabled
+		case "browse.pageSize":
+			size, err := strconv.Atoi(value)
+			if err != nil || size < 0 {
+				return fmt.Errorf("invalid value for %s: %q (expected a number, or 0 to fit the screen)", key, value)
+			}
+			if size > 0 && (size < prefetch.MinPageSize || size > prefetch.MaxPageSize) {
+				return fmt.Errorf("%s must be between %d and %d", key, prefetch.MinPageSize, prefetch.MaxPageSize)
+			}
+			cfg.Browse.PageSize = size
+		case "git.timeout":
+			if value != "" {
+				if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+					return fmt.Errorf("invalid value for %s: %q (expected a duration such as 10m)", key, value)
+				}
+			}
+			cfg.Git.Timeout = value
+		case "git.cloneDepth":
+			depth, err := strconv.Atoi(value)
+			if err != nil || depth < 0 {
+				return fmt.Errorf("invalid value for %s: %q (expected a number of commits, or 0 for full history)", key, value)
+			}
+			cfg.Git.CloneDepth = &depth
+		case "updates.check":
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value for %s: %q (expected true or false)", key, value)
+			}
+			cfg.Updates.DisableCheck = !enabled
+		case "updates.baseUrl":
+			if value != "" && !strings.HasPrefix(value, "https://") {
+				return fmt.Errorf("invalid value for %s: %q (expected an https URL)", key, value)
+			}
+			cfg.Updates.BaseURL = value
+		case "ui.spinner":
+			if _, ok := components.SpinnerStyle(value); !ok {
+				return fmt.Errorf("invalid value for %s: %q (expected one of %s)", key, value, strings.Join(components.SpinnerStyleNames(), ", "))
+			}
+			cfg.UI.Spinner = strings.ToLower(value)
+		case "ui.idleTimeout":
+			if value != "" {
+				if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+					return fmt.Errorf("invalid value for %s: %q (expected a duration such as 10m)", key, value)
+				}
+			}
+			cfg.UI.IdleTimeout = value
 		default:
-			return fmt.Errorf("unknown config key: %s", key)
+			action, ok := keyBindingAction(key)
+			if !ok {
+				return fmt.Errorf("unknown config key: %s", key)
+			}
+			if value == "" {
+				delete(cfg.KeyBindings, action)
+				break
+			}
+			var keys []string
+			for _, k := range strings.Split(value, ",") {
+				if k = strings.TrimSpace(k); k != "" {
+					keys = append(keys, k)
+				}
+			}
+			if cfg.KeyBindings == nil {
+				cfg.KeyBindings = map[string][]string{}
+			}
+			// Only complain about problems this change introduces
+			existing := tui.SetKeyBindings(cfg.KeyBindings)
+			cfg.KeyBindings[action] = keys
+			for _, warning := range tui.SetKeyBindings(cfg.KeyBindings) {
+				if !slices.Contains(existing, warning) {
+					return fmt.Errorf("invalid value for %s: %s", key, warning)
+				}
+			}
 		}
 
 		if err := config.Save(cfg); err != nil {
@@ -86,6 +217,13 @@ var configListCmd = &cobra.Command{
 	},
 }
 
+// keyBindingAction returns the TUI action named by a keybindings.<action>
+// config key
+func keyBindingAction(key string) (string, bool) {
+	action, ok := strings.CutPrefix(key, "keybindings.")
+	return action, ok && slices.Contains(tui.KeyActions, action)
+}
+
 func init() {
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// confirm asks question with a [y/N] prompt and reports whether the user
+// answered yes. The prompt goes to stderr so it never mixes with output
+// meant for a pipe. If stdin closes without an answer, e.g. under --yes
+// with nobody to ask, it fails rather than taking that as a no.
+func confirm(question string) (bool, error) {
+	return ask(question, false)
+}
+
+// confirmDefaultYes is confirm with a [Y/n] prompt, where just pressing
+// enter answers yes
+func confirmDefaultYes(question string) (bool, error) {
+	return ask(question, true)
+}
+
+func ask(question string, defaultYes bool) (bool, error) {
+	choices := "[y/N]"
+	if defaultYes {
+		choices = "[Y/n]"
+	}
+	fmt.Fprintf(os.Stderr, "%s %s ", question, choices)
+	response, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && response == "" {
+		fmt.Fprintln(os.Stderr)
+		return false, fmt.Errorf("failed to read response: %w", err)
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response == "" {
+		return defaultYes, nil
+	}
+	return response == "y" || response == "yes", nil
+}
@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	kioskexec "github.com/reflective-technologies/kiosk-cli/internal/exec"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
+	"golang.org/x/term"
+)
+
+// maxConflictStatusLines caps how much git status output the conflict
+// screen shows
+const maxConflictStatusLines = 12
+
+// conflictAction is the user's choice after re-applying stashed local
+// changes produced merge conflicts
+type conflictAction int
+
+const (
+	conflictCancel conflictAction = iota
+	conflictKeepStash
+	conflictDropStash
+)
+
+// conflictButtons are the conflict screen's choices, in display order
+var conflictButtons = []string{"Open Folder", "Keep Stash", "Drop Stash", "Cancel"}
+
+// conflictRefreshKey reloads the git status shown on the conflict screen
+var conflictRefreshKey = tui.ViewKey("conflict", key.NewBinding(
+	key.WithKeys("r"),
+	key.WithHelp("r", "refresh"),
+))
+
+// resolveStashConflicts blocks launching an app whose update left conflicts
+// from re-applying stashed local changes until the user decides what to do
+// with them. Without a terminal to ask on, the app launches as before and
+// Claude is asked to resolve the conflicts.
+func resolveStashConflicts(appPath string, info *updateInfo, sessionCfg *claudeSessionConfig) error {
+	var in io.Reader = os.Stdin
+	var out io.Writer = os.Stdout
+	if sessionCfg != nil {
+		if sessionCfg.IO.Stdin != nil {
+			in = sessionCfg.IO.Stdin
+		}
+		if sessionCfg.IO.Stdout != nil {
+			out = sessionCfg.IO.Stdout
+		}
+	}
+	if f, ok := in.(*os.File); !ok || !term.IsTerminal(int(f.Fd())) {
+		return nil
+	}
+
+	loadKeyBindings()
+	m := newConflictModel(appPath)
+	finalModel, err := runProgram(m, tea.WithInput(in), tea.WithOutput(out))
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	action := conflictCancel
+	if model, ok := finalModel.(*conflictModel); ok {
+		action = model.action
+	}
+
+	switch action {
+	case conflictKeepStash:
+		return nil
+	case conflictDropStash:
+		if err := gitRun(appPath, "stash", "drop"); err != nil {
+			return fmt.Errorf("failed to drop stash: %w", err)
+		}
+		info.stashDropped = true
+		return nil
+	default:
+		return fmt.Errorf("launch cancelled: %s has unresolved conflicts from your local changes (they are still saved in git stash)", appPath)
+	}
+}
+
+// conflictStatusMsg carries refreshed git status output
+type conflictStatusMsg struct {
+	status string
+	err    error
+}
+
+// conflictEditorMsg is sent when a terminal editor opened from the conflict
+// screen exits
+type conflictEditorMsg struct {
+	err error
+}
+
+// conflictModel is the bubbletea model for resolving stash conflicts
+type conflictModel struct {
+	appPath string
+	status  string
+	notice  string
+	cursor  int
+	action  conflictAction
+	keys    tui.KeyMap
+}
+
+func newConflictModel(appPath string) *conflictModel {
+	return &conflictModel{
+		appPath: appPath,
+		cursor:  1, // Keep Stash: launches without discarding anything
+		keys:    tui.DefaultKeyMap(),
+	}
+}
+
+func (m *conflictModel) Init() tea.Cmd {
+	return m.refreshStatus()
+}
+
+func (m *conflictModel) refreshStatus() tea.Cmd {
+	appPath := m.appPath
+	return func() tea.Msg {
+		status, err := gitOutput(appPath, "status", "--short")
+		return conflictStatusMsg{status: status, err: err}
+	}
+}
+
+func (m *conflictModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case conflictStatusMsg:
+		if msg.err != nil {
+			m.status = msg.err.Error()
+		} else {
+			m.status = msg.status
+		}
+		return m, nil
+
+	case conflictEditorMsg:
+		if msg.err != nil {
+			m.notice = fmt.Sprintf("Editor exited with error: %v", msg.err)
+		}
+		return m, m.refreshStatus()
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Quit, m.keys.Back):
+			m.action = conflictCancel
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Left):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, m.keys.Right, m.keys.Tab):
+			if m.cursor < len(conflictButtons)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, conflictRefreshKey):
+			return m, m.refreshStatus()
+		case key.Matches(msg, m.keys.Enter):
+			switch m.cursor {
+			case 0:
+				return m, m.openFolder()
+			case 1:
+				m.action = conflictKeepStash
+			case 2:
+				m.action = conflictDropStash
+			default:
+				m.action = conflictCancel
+			}
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// openFolder opens the app directory in the user's editor so conflicts can
+// be resolved by hand
+func (m *conflictModel) openFolder() tea.Cmd {
+	cfg, err := config.Load()
+	if err != nil {
+		m.notice = fmt.Sprintf("Failed to load config: %v", err)
+		return nil
+	}
+
+	editorCmd, terminal, err := kioskexec.EditorCmd(m.appPath, "", cfg.Editor)
+	if err != nil {
+		m.notice = err.Error()
+		return nil
+	}
+
+	if terminal {
+		return tea.ExecProcess(editorCmd, func(err error) tea.Msg {
+			return conflictEditorMsg{err: err}
+		})
+	}
+	if err := editorCmd.Start(); err != nil {
+		m.notice = fmt.Sprintf("Failed to open editor: %v", err)
+		return nil
+	}
+	m.notice = fmt.Sprintf("Opened %s. Press r to refresh the status once you're done.", m.appPath)
+	return nil
+}
+
+func (m *conflictModel) View() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Warning)
+	b.WriteString("  ")
+	b.WriteString(titleStyle.Render("Unresolved Conflicts"))
+	b.WriteString("\n\n")
+
+	b.WriteString("  ")
+	b.WriteString("The app was updated, but your local changes conflicted with it when re-applied.\n")
+	b.WriteString("  ")
+	b.WriteString(styles.MutedStyle.Render("Your changes are still saved in git stash until you drop it."))
+	b.WriteString("\n\n")
+
+	if status := strings.TrimSpace(m.status); status != "" {
+		lines := strings.Split(status, "\n")
+		extra := 0
+		if len(lines) > maxConflictStatusLines {
+			extra = len(lines) - maxConflictStatusLines
+			lines = lines[:maxConflictStatusLines]
+		}
+		for _, line := range lines {
+			b.WriteString("    ")
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+		if extra > 0 {
+			b.WriteString("    ")
+			b.WriteString(styles.MutedStyle.Render(fmt.Sprintf("... and %d more", extra)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("  ")
+	b.WriteString(styles.MutedStyle.Render(conflictHints[m.cursor]))
+	b.WriteString("\n\n")
+
+	b.WriteString("  ")
+	b.WriteString(m.renderButtons())
+	b.WriteString("\n\n")
+
+	if m.notice != "" {
+		b.WriteString("  ")
+		b.WriteString(m.notice)
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString("  ")
+	b.WriteString(styles.MutedStyle.Render(tui.HelpLine(
+		tui.Combined("select", m.keys.Left, m.keys.Right),
+		tui.WithHelp(m.keys.Enter, "confirm"),
+		conflictRefreshKey,
+		tui.WithHelp(m.keys.Back, "cancel"),
+	)))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// conflictHints describes each button in conflictButtons
+var conflictHints = []string{
+	"Open the app directory in your editor to resolve the conflicts yourself.",
+	"Launch the app and have Claude resolve the conflicts; the stash is kept as a backup.",
+	"Launch the app and discard the stash. Only do this once the conflicts are resolved.",
+	"Don't launch the app. Conflicts and the stash are left as they are.",
+}
+
+func (m *conflictModel) renderButtons() string {
+	buttons := make([]string, len(conflictButtons))
+	for i, label := range conflictButtons {
+		style := lipgloss.NewStyle().Padding(0, 2)
+		if i == m.cursor {
+			bg := styles.Primary
+			if i == 2 {
+				bg = styles.Error
+			}
+			style = style.Background(bg).Foreground(lipgloss.Color("#FFFFFF"))
+		} else {
+			style = style.Foreground(styles.Muted)
+		}
+		buttons[i] = style.Render(label)
+	}
+	return strings.Join(buttons, "  ")
+}
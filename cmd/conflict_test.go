@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui"
+)
+
+func TestConflictModelFollowsKeyBindings(t *testing.T) {
+	tui.SetKeyBindings(map[string][]string{"right": {"ctrl+f"}, "enter": {"ctrl+o"}})
+	defer tui.SetKeyBindings(nil)
+
+	m := newConflictModel(t.TempDir())
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+
+	// l is no longer bound, so ctrl+f alone moves from Keep Stash to Drop Stash
+	if m.action != conflictDropStash {
+		t.Errorf("action = %v, want conflictDropStash", m.action)
+	}
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	"golang.org/x/term"
+)
+
+// exitCrashed is the exit code after a panic, matching Go's own
+const exitCrashed = 2
+
+// saveTerminalState records the terminal mode so a crash can restore it,
+// returning nil when stdin isn't a terminal
+func saveTerminalState() *term.State {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil
+	}
+	state, err := term.GetState(fd)
+	if err != nil {
+		return nil
+	}
+	return state
+}
+
+// handleCrash reports a panic instead of dumping a raw stack trace. It must
+// be deferred directly. The report is only written to a local file; nothing
+// is transmitted.
+func handleCrash(state *term.State) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	r, stack := crashDetails(r)
+
+	// Undo anything a TUI or Claude session left behind: raw mode, the
+	// alternate screen and a hidden cursor
+	if state != nil {
+		_ = term.Restore(int(os.Stdin.Fd()), state)
+		fmt.Fprint(os.Stderr, "\033[?1049l\033[?25h")
+	}
+
+	path, err := writeCrashLog(r, stack)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "kiosk crashed: %v\n\n%s\n", r, stack)
+		os.Exit(exitCrashed)
+	}
+	fmt.Fprintf(os.Stderr, "kiosk crashed; details saved to %s\n", path)
+	fmt.Fprintf(os.Stderr, "Please file an issue at https://github.com/%s/%s/issues and attach that file.\n", repoOwner, repoName)
+	os.Exit(exitCrashed)
+}
+
+// crashDetails returns the panic value and stack trace to report for r,
+// unwrapping a panic raised again by runProgram
+func crashDetails(r any) (any, []byte) {
+	if p, ok := r.(programPanic); ok {
+		return p.value, p.stack
+	}
+	return r, debug.Stack()
+}
+
+// programPanic is a panic inside a Bubble Tea program, raised again by
+// runProgram once the program has restored the terminal
+type programPanic struct {
+	value any
+	stack []byte
+}
+
+// panicGuard wraps a program's model to record a panic in Init, Update or
+// View along with its stack. Bubble Tea recovers the panic itself and Run
+// only returns tea.ErrProgramPanic, so the details would otherwise be lost.
+type panicGuard struct {
+	model  tea.Model
+	caught *programPanic
+}
+
+func (g *panicGuard) Init() tea.Cmd {
+	defer g.record()
+	return g.model.Init()
+}
+
+func (g *panicGuard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer g.record()
+	var cmd tea.Cmd
+	g.model, cmd = g.model.Update(msg)
+	return g, cmd
+}
+
+func (g *panicGuard) View() string {
+	defer g.record()
+	return g.model.View()
+}
+
+// record notes a panic in progress and lets it carry on to Bubble Tea
+func (g *panicGuard) record() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if g.caught == nil {
+		g.caught = &programPanic{value: r, stack: debug.Stack()}
+	}
+	panic(r)
+}
+
+// runProgram runs a Bubble Tea program over m and returns its final model.
+// A panic inside the program is raised again once Bubble Tea has restored
+// the terminal, so handleCrash writes a crash log for it.
+func runProgram(m tea.Model, opts ...tea.ProgramOption) (tea.Model, error) {
+	guard := &panicGuard{model: m}
+	_, err := tea.NewProgram(guard, opts...).Run()
+	if errors.Is(err, tea.ErrProgramPanic) {
+		if guard.caught != nil {
+			panic(*guard.caught)
+		}
+		// A command's goroutine panicked; Bubble Tea has printed its trace
+		panic(programPanic{value: err, stack: []byte("(stack trace printed above)\n")})
+	}
+	return guard.model, err
+}
+
+// writeCrashLog writes the panic, stack trace and build details to
+// ~/.kiosk/crash-<timestamp>.log, returning its path
+func writeCrashLog(r any, stack []byte) (string, error) {
+	now := time.Now()
+	info := currentBuildInfo()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "kiosk %s (commit %s, %s, %s/%s)\n", info.Version, info.Commit, info.GoVersion, info.OS, info.Arch)
+	fmt.Fprintf(&b, "time: %s\n", now.Format(time.RFC3339))
+	fmt.Fprintf(&b, "command: %s\n\n", strings.Join(os.Args, " "))
+	fmt.Fprintf(&b, "panic: %v\n\n%s", r, stack)
+
+	if err := os.MkdirAll(config.KioskDir(), 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(config.KioskDir(), fmt.Sprintf("crash-%s.log", now.Format("20060102-150405")))
+	if err := os.WriteFile(path, []byte(b.String()), 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
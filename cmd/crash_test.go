@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+)
+
+// panicModel panics on its first update, like a view handed a nil app
+type panicModel struct{}
+
+func (panicModel) Init() tea.Cmd {
+	return func() tea.Msg { return "go" }
+}
+
+func (panicModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg == "go" {
+		var app *struct{ name string }
+		_ = app.name
+	}
+	return panicModel{}, nil
+}
+
+func (panicModel) View() string { return "" }
+
+func TestPanickingProgramWritesCrashLog(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(config.EnvConfig, filepath.Join(dir, "config.json"))
+
+	var path string
+	func() {
+		defer func() {
+			r := recover()
+			if r == nil {
+				t.Fatal("runProgram() returned, want the program's panic raised again")
+			}
+			value, stack := crashDetails(r)
+			var err error
+			if path, err = writeCrashLog(value, stack); err != nil {
+				t.Fatal(err)
+			}
+		}()
+		_, _ = runProgram(panicModel{}, tea.WithInput(strings.NewReader("")), tea.WithOutput(&strings.Builder{}))
+	}()
+
+	if filepath.Dir(path) != dir {
+		t.Errorf("crash log written to %s, want a file in %s", path, dir)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "nil pointer dereference") || !strings.Contains(string(data), "panicModel.Update") {
+		t.Errorf("crash log doesn't describe the panic in Update:\n%s", data)
+	}
+}
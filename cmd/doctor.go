@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/reflective-technologies/kiosk-cli/internal/api"
+	"github.com/reflective-technologies/kiosk-cli/internal/auth"
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	kioskexec "github.com/reflective-technologies/kiosk-cli/internal/exec"
+	"github.com/reflective-technologies/kiosk-cli/internal/git"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
+	"github.com/spf13/cobra"
+)
+
+var doctorJSON bool
+
+// doctorStatus is the outcome of one check. Only failures make doctor exit
+// non-zero; warnings are things kiosk can work without.
+type doctorStatus string
+
+const (
+	doctorOK   doctorStatus = "ok"
+	doctorWarn doctorStatus = "warn"
+	doctorFail doctorStatus = "fail"
+)
+
+// doctorResult is one check's outcome, as printed by --json
+type doctorResult struct {
+	Check       string       `json:"check"`
+	Status      doctorStatus `json:"status"`
+	Detail      string       `json:"detail"`
+	Remediation string       `json:"remediation,omitempty"`
+}
+
+// doctorEnv is what the checks inspect
+type doctorEnv struct {
+	cfg    *config.Config
+	cfgErr error
+}
+
+// doctorCheck is one thing kiosk doctor verifies
+type doctorCheck struct {
+	name string
+	run  func(env *doctorEnv) doctorResult
+}
+
+// doctorChecks are run in order; both output formats iterate this list
+var doctorChecks = []doctorCheck{
+	{"git", func(env *doctorEnv) doctorResult {
+		if !git.Available() {
+			return doctorResult{Status: doctorFail, Detail: "git is not installed or not in your PATH",
+				Remediation: "install git from https://git-scm.com/downloads or with your package manager"}
+		}
+		version, err := gitOutput("", "--version")
+		if err != nil {
+			return doctorResult{Status: doctorWarn, Detail: fmt.Sprintf("git is installed but didn't run: %v", err)}
+		}
+		return doctorResult{Status: doctorOK, Detail: version}
+	}},
+	{"claude", func(env *doctorEnv) doctorResult {
+		if path := kioskexec.ClaudePath(); path != "" {
+			if err := kioskexec.CheckClaudePath(path); err != nil {
+				return doctorResult{Status: doctorFail, Detail: err.Error(),
+					Remediation: "fix $" + config.EnvClaudePath + " or kiosk config set claudePath, or unset them to use claude from PATH"}
+			}
+			return doctorResult{Status: doctorOK, Detail: path}
+		}
+		if !kioskexec.ClaudeAvailable() {
+			return doctorResult{Status: doctorFail, Detail: "claude is not installed or not in your PATH",
+				Remediation: "npm install -g @anthropic-ai/claude-code"}
+		}
+		return doctorResult{Status: doctorOK, Detail: kioskexec.ResolveClaude()}
+	}},
+	{"config", func(env *doctorEnv) doctorResult {
+		if env.cfgErr != nil {
+			return doctorResult{Status: doctorFail, Detail: env.cfgErr.Error(),
+				Remediation: fmt.Sprintf("fix or remove %s", config.ConfigPath())}
+		}
+		return doctorResult{Status: doctorOK, Detail: config.ConfigPath()}
+	}},
+	{"apps directory", func(env *doctorEnv) doctorResult {
+		dir := config.AppsDir()
+		if err := checkWritableDir(dir); err != nil {
+			return doctorResult{Status: doctorFail, Detail: err.Error(),
+				Remediation: "make it writable, or move it with kiosk config set appsDir <path>"}
+		}
+		return doctorResult{Status: doctorOK, Detail: dir}
+	}},
+	{"api", func(env *doctorEnv) doctorResult {
+		if err := api.NewClient(env.cfg.APIUrl).Ping(); err != nil {
+			return doctorResult{Status: doctorFail, Detail: fmt.Sprintf("%s is unreachable: %v", env.cfg.APIUrl, err),
+				Remediation: "check your network connection, or the apiUrl setting"}
+		}
+		return doctorResult{Status: doctorOK, Detail: env.cfg.APIUrl}
+	}},
+	{"login", func(env *doctorEnv) doctorResult {
+		if !auth.IsLoggedIn() {
+			return doctorResult{Status: doctorWarn, Detail: "not logged in; browsing and running apps still work",
+				Remediation: "kiosk login"}
+		}
+		return doctorResult{Status: doctorOK, Detail: "logged in"}
+	}},
+}
+
+// checkWritableDir creates dir if needed and checks a file can be written
+// in it
+func checkWritableDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("can't create %s: %w", dir, err)
+	}
+	f, err := os.CreateTemp(dir, ".kiosk-doctor-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", dir, err)
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
+// runDoctorChecks runs every check, returning the results and how many
+// failed
+func runDoctorChecks(env *doctorEnv) (results []doctorResult, failed int) {
+	for _, check := range doctorChecks {
+		result := check.run(env)
+		result.Check = check.name
+		if result.Status == doctorFail {
+			failed++
+		}
+		results = append(results, result)
+	}
+	return results, failed
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that kiosk is set up correctly",
+	Long: `Check the tools, configuration, and connectivity kiosk needs, and suggest
+fixes for anything missing. Exits non-zero if any check fails; warnings
+don't count.
+
+With --json the results are printed as a JSON object for scripts.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		env := &doctorEnv{}
+		env.cfg, env.cfgErr = config.Load()
+		if env.cfgErr != nil {
+			env.cfg = config.Default()
+		}
+
+		results, failed := runDoctorChecks(env)
+
+		if doctorJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(struct {
+				OK     bool           `json:"ok"`
+				Checks []doctorResult `json:"checks"`
+			}{failed == 0, results}); err != nil {
+				return err
+			}
+		} else {
+			printDoctorResults(results)
+		}
+
+		if failed > 0 {
+			checks := "checks"
+			if failed == 1 {
+				checks = "check"
+			}
+			return fmt.Errorf("%d %s failed", failed, checks)
+		}
+		return nil
+	},
+}
+
+// printDoctorResults prints one styled line per check, with its fix below
+func printDoctorResults(results []doctorResult) {
+	nameStyle := lipgloss.NewStyle().Bold(true).Width(16)
+	fmt.Println()
+	for _, r := range results {
+		var mark string
+		switch r.Status {
+		case doctorOK:
+			mark = styles.SuccessStyle.Render("✓")
+		case doctorWarn:
+			mark = styles.WarningStyle.Render("!")
+		default:
+			mark = styles.ErrorStyle.Render("✗")
+		}
+		fmt.Printf("  %s %s%s\n", mark, nameStyle.Render(r.Check), r.Detail)
+		if r.Remediation != "" {
+			fmt.Printf("    %s\n", styles.MutedStyle.Render("→ "+r.Remediation))
+		}
+	}
+	fmt.Println()
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "print results as JSON")
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
+	"github.com/spf13/cobra"
+)
+
+var duJSON bool
+
+// appUsage is the disk usage of one installed app
+type appUsage struct {
+	Key     string `json:"key"`
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	Missing bool   `json:"missing,omitempty"` // in the index, but its directory is gone
+}
+
+var duCmd = &cobra.Command{
+	Use:   "du",
+	Short: "Show disk usage of installed apps",
+	Long: `Show how much disk space each installed app takes, largest first, and the
+total. Apps whose directory is missing are flagged and count as 0.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		idx, err := appindex.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load app index: %w", err)
+		}
+
+		usage := make([]appUsage, 0, idx.Count())
+		var total int64
+		for _, key := range idx.List() {
+			u := appUsage{Key: key, Path: idx.Path(key)}
+			if _, err := os.Stat(u.Path); os.IsNotExist(err) {
+				u.Missing = true
+			} else if u.Size, err = appindex.DirSize(u.Path); err != nil {
+				return fmt.Errorf("failed to measure %s: %w", key, err)
+			}
+			total += u.Size
+			usage = append(usage, u)
+		}
+		sort.SliceStable(usage, func(i, j int) bool {
+			return usage[i].Size > usage[j].Size
+		})
+
+		if duJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(struct {
+				Apps  []appUsage `json:"apps"`
+				Total int64      `json:"total"`
+			}{usage, total})
+		}
+
+		if len(usage) == 0 {
+			fmt.Println()
+			fmt.Println(styles.MutedStyle.Render("  No apps installed."))
+			fmt.Println()
+			return nil
+		}
+
+		sizeStyle := lipgloss.NewStyle().Width(10).Align(lipgloss.Right)
+		fmt.Println()
+		for _, u := range usage {
+			fmt.Print("  ")
+			fmt.Print(sizeStyle.Render(appindex.FormatSize(u.Size)))
+			fmt.Print("  ")
+			fmt.Print(u.Key)
+			if u.Missing {
+				fmt.Print("  " + styles.WarningStyle.Render("directory missing (try 'kiosk rm "+u.Key+"')"))
+			}
+			fmt.Println()
+		}
+		fmt.Println()
+		fmt.Print("  ")
+		fmt.Print(lipgloss.NewStyle().Bold(true).Inherit(sizeStyle).Render(appindex.FormatSize(total)))
+		fmt.Println("  total")
+		fmt.Println()
+
+		return nil
+	},
+}
+
+func init() {
+	duCmd.Flags().BoolVar(&duJSON, "json", false, "print usage as JSON")
+	rootCmd.AddCommand(duCmd)
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	kioskerrors "github.com/reflective-technologies/kiosk-cli/internal/errors"
+	"github.com/reflective-technologies/kiosk-cli/internal/git"
+)
+
+// defaultGitTimeout bounds a single git command when git.timeout isn't set
+const defaultGitTimeout = 5 * time.Minute
+
+// gitClient runs kiosk's git commands, each limited by the git.timeout
+// config key
+var gitClient = sync.OnceValue(func() *git.Git {
+	timeout := defaultGitTimeout
+	if cfg, err := config.Load(); err == nil && cfg.Git.Timeout != "" {
+		if d, err := time.ParseDuration(cfg.Git.Timeout); err == nil && d > 0 {
+			timeout = d
+		}
+	}
+	g := git.New(timeout)
+	g.TimeoutHint = "set git.timeout to allow longer"
+	return g
+})
+
+// requireGit fails with install guidance if git isn't on PATH. need says
+// what git is required for, e.g. "install apps".
+func requireGit(need string) error {
+	if git.Available() {
+		return nil
+	}
+	return kioskerrors.NewDependencyError("git", fmt.Sprintf(
+		"git is required to %s. Install it from https://git-scm.com/downloads\nor with your package manager, then try again.", need))
+}
+
+// defaultCloneDepth keeps clones shallow unless full history is asked for
+const defaultCloneDepth = 1
+
+// cloneDepth is how many commits of history new clones get, from
+// kiosk run --depth or the git.cloneDepth config key. 0 means all of it.
+func cloneDepth() int {
+	if runDepthSet && runDepthFlag >= 0 {
+		return runDepthFlag
+	}
+	if cfg, err := config.Load(); err == nil && cfg.Git.CloneDepth != nil && *cfg.Git.CloneDepth >= 0 {
+		return *cfg.Git.CloneDepth
+	}
+	return defaultCloneDepth
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	return gitClient().Output(context.Background(), dir, args...)
+}
+
+// excludeFromGit adds pattern to the info/exclude file of the git checkout
+// dir is in, so files kiosk writes there don't show up as local changes.
+// Directories that aren't in a checkout are left alone.
+func excludeFromGit(dir, pattern string) error {
+	if !git.Available() {
+		return nil
+	}
+	excludePath, err := gitOutput(dir, "rev-parse", "--git-path", "info/exclude")
+	if err != nil {
+		return nil
+	}
+	if !filepath.IsAbs(excludePath) {
+		excludePath = filepath.Join(dir, excludePath)
+	}
+
+	data, err := os.ReadFile(excludePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", excludePath, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == pattern {
+			return nil
+		}
+	}
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		pattern = "\n" + pattern
+	}
+
+	if err := os.MkdirAll(filepath.Dir(excludePath), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(excludePath), err)
+	}
+	f, err := os.OpenFile(excludePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", excludePath, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(pattern + "\n"); err != nil {
+		return fmt.Errorf("failed to update %s: %w", excludePath, err)
+	}
+	return nil
+}
+
+// originURL returns the URL of dir's origin remote
+func originURL(dir string) (string, error) {
+	return gitOutput(dir, "remote", "get-url", "origin")
+}
+
+func gitRun(dir string, args ...string) error {
+	return gitClient().Run(context.Background(), dir, args...)
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/reflective-technologies/kiosk-cli/internal/events"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
+	"github.com/spf13/cobra"
+)
+
+var historyLimit int
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show recent app activity from the local event log",
+	Long: `Show installs, runs, updates, and removals recorded in ~/.kiosk/events.jsonl.
+
+The event log is opt-in and never leaves this machine. Enable it with:
+  kiosk config set telemetry.localLog true`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log, err := events.Load()
+		if err != nil {
+			return err
+		}
+
+		if len(log) == 0 {
+			fmt.Println()
+			if events.Enabled() {
+				fmt.Println(styles.MutedStyle.Render("  No activity recorded yet."))
+			} else {
+				fmt.Println(styles.MutedStyle.Render("  Local event log is disabled."))
+				fmt.Println()
+				fmt.Println("  Run " + lipgloss.NewStyle().Bold(true).Render("kiosk config set telemetry.localLog true") + " to enable it.")
+			}
+			fmt.Println()
+			return nil
+		}
+
+		// Show newest first
+		start := 0
+		if historyLimit > 0 && len(log) > historyLimit {
+			start = len(log) - historyLimit
+		}
+
+		typeStyle := lipgloss.NewStyle().Foreground(styles.Secondary).Width(9)
+
+		fmt.Println()
+		for i := len(log) - 1; i >= start; i-- {
+			e := log[i]
+			fmt.Print("  ")
+			fmt.Print(styles.MutedStyle.Render(e.Time.Local().Format("2006-01-02 15:04")))
+			fmt.Print("  ")
+			fmt.Print(typeStyle.Render(string(e.Type)))
+			fmt.Println(e.AppKey)
+		}
+		fmt.Println()
+
+		return nil
+	},
+}
+
+func init() {
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "maximum number of events to show (0 for all)")
+	rootCmd.AddCommand(historyCmd)
+}
@@ -3,13 +3,18 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/reflective-technologies/kiosk-cli/internal/api"
 	"github.com/reflective-technologies/kiosk-cli/internal/auth"
 	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	"github.com/reflective-technologies/kiosk-cli/internal/kioskmd"
 	"github.com/spf13/cobra"
 )
 
+var initForce bool
+var initTemplate bool
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Create a KIOSK.md file for your repository",
@@ -18,8 +23,37 @@ var initCmd = &cobra.Command{
 Claude Code will analyze your project and create a KIOSK.md file with
 installation instructions for users who install your app.
 
+Use --template to write a starter KIOSK.md to fill in yourself instead;
+this doesn't need Claude or a login.
+
+An existing KIOSK.md is left alone unless you pass --force.
+
 Run this command from within a git repository.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get current working directory
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+
+		existing := kioskmd.Find(cwd)
+		if existing != "" && !initForce {
+			return fmt.Errorf("%s already exists; pass --force to replace it", filepath.Base(existing))
+		}
+
+		if initTemplate {
+			path := existing
+			if path == "" {
+				path = filepath.Join(cwd, "KIOSK.md")
+			}
+			if err := os.WriteFile(path, []byte(kioskMdTemplate), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+			}
+			fmt.Printf("Created %s\n", filepath.Base(path))
+			fmt.Println("Edit it with your app's installation instructions, then run 'kiosk publish'.")
+			return nil
+		}
+
 		// Check authentication
 		if !auth.IsLoggedIn() {
 			return fmt.Errorf("not logged in, run 'kiosk login' first")
@@ -31,12 +65,6 @@ Run this command from within a git repository.`,
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
-		// Get current working directory
-		cwd, err := os.Getwd()
-		if err != nil {
-			return fmt.Errorf("failed to get current directory: %w", err)
-		}
-
 		client := api.NewClient(cfg.APIUrl)
 
 		// Fetch the init prompt
@@ -54,4 +82,6 @@ Run this command from within a git repository.`,
 
 func init() {
 	rootCmd.AddCommand(initCmd)
+	initCmd.Flags().BoolVar(&initForce, "force", false, "replace an existing KIOSK.md")
+	initCmd.Flags().BoolVar(&initTemplate, "template", false, "write a starter KIOSK.md instead of having Claude create one")
 }
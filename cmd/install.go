@@ -21,6 +21,15 @@ The app can be specified as:
 func init() {
 	rootCmd.AddCommand(installCmd)
 	// Add the same flags as run command
-	installCmd.Flags().StringVar(&sandboxFlag, "sandbox", "", "sandbox mode: comma-separated list of 'default', 'fs', 'net'")
+	installCmd.Flags().StringVar(&sandboxFlag, "sandbox", "", "sandbox mode: comma-separated list of 'default', 'fs', 'net', 'net:<domain>'; overrides the app's defaults")
+	installCmd.Flags().BoolVar(&noSandboxFlag, "no-sandbox", false, "don't apply the sandbox defaults from the app's KIOSK.md")
+	installCmd.MarkFlagsMutuallyExclusive("no-sandbox", "sandbox")
 	installCmd.Flags().BoolVar(&safeFlag, "safe", false, "run with default permission mode (prompts for permissions)")
+	installCmd.Flags().StringVar(&claudePathFlag, "claude-path", "", "claude binary to run instead of the one in PATH")
+	installCmd.Flags().BoolVar(&runForceFlag, "force", false, "replace an installed app with the same name from another repository")
+	installCmd.Flags().BoolVar(&runSkipFlag, "skip", false, "keep an installed app with the same name from another repository and run it")
+	installCmd.MarkFlagsMutuallyExclusive("force", "skip")
+	installCmd.Flags().BoolVar(&installTUIFlag, "tui", false, "install in the full-screen view, showing the clone and Claude's setup output as they run")
+	// Claude runs in print mode there, so it can't ask for permissions
+	installCmd.MarkFlagsMutuallyExclusive("tui", "safe")
 }
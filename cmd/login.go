@@ -1,16 +1,18 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os/exec"
-	"runtime"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/reflective-technologies/kiosk-cli/internal/api"
 	"github.com/reflective-technologies/kiosk-cli/internal/auth"
 	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	kioskexec "github.com/reflective-technologies/kiosk-cli/internal/exec"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
 	"github.com/spf13/cobra"
 )
@@ -54,6 +56,11 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	// Fail fast if the server is down rather than partway through the flow
+	if err := api.NewClient(cfg.APIUrl).Ping(); err != nil {
+		return err
+	}
+
 	// Create device flow handler pointing to Kiosk API
 	flow := auth.NewDeviceFlow(cfg.APIUrl)
 
@@ -63,13 +70,12 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	}
 
 	// Try to open browser automatically
-	openBrowser(deviceCode.VerificationURI)
+	kioskexec.OpenBrowser(deviceCode.VerificationURI)
 
 	// Run interactive login UI
 	m := newLoginModel(deviceCode, flow, loginTimeout)
-	p := tea.NewProgram(m)
-
-	finalModel, err := p.Run()
+	defer m.cancel()
+	finalModel, err := runProgram(m)
 	if err != nil {
 		return fmt.Errorf("error: %w", err)
 	}
@@ -127,6 +133,12 @@ type loginModel struct {
 	deviceCode        *auth.DeviceCodeResponse
 	flow              *auth.DeviceFlow
 	timeout           time.Duration
+	ctx               context.Context
+	cancel            context.CancelFunc
+	expiresAt         time.Time          // when the device code expires (zero if unknown)
+	deadline          time.Time          // when polling gives up
+	interval          time.Duration      // set once the server asks us to slow down
+	slowDowns         chan time.Duration // new intervals from the poll
 	authResp          *auth.AuthResponse
 	err               error
 	polling           bool
@@ -138,17 +150,32 @@ type loginModel struct {
 func newLoginModel(deviceCode *auth.DeviceCodeResponse, flow *auth.DeviceFlow, timeout time.Duration) *loginModel {
 	// Try to copy code to clipboard
 	copied := false
-	if err := copyToClipboard(deviceCode.UserCode); err == nil {
+	if err := kioskexec.CopyToClipboard(deviceCode.UserCode); err == nil {
 		copied = true
 	}
 
-	return &loginModel{
+	if timeout <= 0 {
+		timeout = auth.DefaultPollTimeout
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	now := time.Now()
+	m := &loginModel{
 		deviceCode:        deviceCode,
 		flow:              flow,
 		timeout:           timeout,
+		ctx:               ctx,
+		cancel:            cancel,
+		deadline:          now.Add(timeout),
 		polling:           true,
 		copiedToClipboard: copied,
+		slowDowns:         make(chan time.Duration, 1),
+	}
+	flow.OnSlowDown = auth.SendSlowDowns(m.slowDowns)
+	if deviceCode.ExpiresIn > 0 {
+		m.expiresAt = now.Add(time.Duration(deviceCode.ExpiresIn) * time.Second)
 	}
+	return m
 }
 
 type pollResultMsg struct {
@@ -156,21 +183,35 @@ type pollResultMsg struct {
 	err  error
 }
 type spinnerTickMsg struct{}
+type slowDownMsg time.Duration
 
 func (m *loginModel) Init() tea.Cmd {
 	return tea.Batch(
 		m.pollForAuth(),
+		m.waitForSlowDown(),
 		m.spinnerTick(),
 	)
 }
 
 func (m *loginModel) pollForAuth() tea.Cmd {
 	return func() tea.Msg {
-		resp, err := m.flow.PollForAuth(m.deviceCode.DeviceCode, m.deviceCode.Interval, m.timeout)
+		defer close(m.slowDowns)
+		resp, err := m.flow.PollForAuth(m.ctx, m.deviceCode.DeviceCode, m.deviceCode.Interval, m.timeout)
 		return pollResultMsg{resp: resp, err: err}
 	}
 }
 
+// waitForSlowDown waits for the poll to report a new interval
+func (m *loginModel) waitForSlowDown() tea.Cmd {
+	return func() tea.Msg {
+		interval, ok := <-m.slowDowns
+		if !ok {
+			return nil
+		}
+		return slowDownMsg(interval)
+	}
+}
+
 func (m *loginModel) spinnerTick() tea.Cmd {
 	return tea.Tick(100*time.Millisecond, func(t time.Time) tea.Msg {
 		return spinnerTickMsg{}
@@ -183,6 +224,7 @@ func (m *loginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "q", "ctrl+c", "esc":
 			m.quitting = true
+			m.cancel()
 			return m, tea.Quit
 		}
 
@@ -192,8 +234,15 @@ func (m *loginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, m.spinnerTick()
 		}
 
+	case slowDownMsg:
+		m.interval = time.Duration(msg)
+		return m, m.waitForSlowDown()
+
 	case pollResultMsg:
 		m.polling = false
+		if errors.Is(msg.err, context.Canceled) {
+			return m, tea.Quit
+		}
 		if msg.err != nil {
 			m.err = msg.err
 		} else {
@@ -257,6 +306,14 @@ func (m *loginModel) View() string {
 		b.WriteString(" ")
 		b.WriteString(styles.MutedStyle.Render("Waiting for authorization..."))
 		b.WriteString("\n")
+		b.WriteString("    ")
+		b.WriteString(styles.MutedStyle.Render(auth.Countdown(time.Now(), m.expiresAt, m.deadline)))
+		b.WriteString("\n")
+		if m.interval > 0 {
+			b.WriteString("    ")
+			b.WriteString(styles.MutedStyle.Render(fmt.Sprintf("Server asked us to slow down; checking every %ds", int(m.interval/time.Second))))
+			b.WriteString("\n")
+		}
 	}
 
 	b.WriteString("\n")
@@ -268,47 +325,3 @@ func (m *loginModel) View() string {
 
 	return b.String()
 }
-
-// openBrowser opens the specified URL in the default browser
-func openBrowser(url string) error {
-	var cmd *exec.Cmd
-
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("open", url)
-	case "linux":
-		cmd = exec.Command("xdg-open", url)
-	case "windows":
-		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
-	default:
-		return fmt.Errorf("unsupported platform")
-	}
-
-	return cmd.Start()
-}
-
-// copyToClipboard copies text to the system clipboard
-func copyToClipboard(text string) error {
-	var cmd *exec.Cmd
-
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("pbcopy")
-	case "linux":
-		// Try xclip first, fall back to xsel
-		if _, err := exec.LookPath("xclip"); err == nil {
-			cmd = exec.Command("xclip", "-selection", "clipboard")
-		} else if _, err := exec.LookPath("xsel"); err == nil {
-			cmd = exec.Command("xsel", "--clipboard", "--input")
-		} else {
-			return fmt.Errorf("no clipboard utility found (install xclip or xsel)")
-		}
-	case "windows":
-		cmd = exec.Command("cmd", "/c", "clip")
-	default:
-		return fmt.Errorf("unsupported platform")
-	}
-
-	cmd.Stdin = strings.NewReader(text)
-	return cmd.Run()
-}
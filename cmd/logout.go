@@ -41,7 +41,7 @@ func runLogout(cmd *cobra.Command, args []string) error {
 	// Check if we should skip interactive confirmation
 	isInteractive := term.IsTerminal(int(os.Stdin.Fd()))
 
-	if logoutForce || !isInteractive {
+	if logoutForce || assumeYes || !isInteractive {
 		// Non-interactive or forced: logout immediately
 		if err := auth.DeleteCredentials(); err != nil {
 			return fmt.Errorf("failed to logout: %w", err)
@@ -54,9 +54,7 @@ func runLogout(cmd *cobra.Command, args []string) error {
 
 	// Run interactive confirmation
 	m := newLogoutModel(user)
-	p := tea.NewProgram(m)
-
-	finalModel, err := p.Run()
+	finalModel, err := runProgram(m)
 	if err != nil {
 		return fmt.Errorf("error: %w", err)
 	}
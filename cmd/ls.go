@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -11,8 +10,10 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
-	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	"github.com/reflective-technologies/kiosk-cli/internal/events"
 	"github.com/reflective-technologies/kiosk-cli/internal/sessions"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/components"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui/views"
 	"github.com/spf13/cobra"
@@ -40,17 +41,16 @@ var lsCmd = &cobra.Command{
 		store, _ := sessions.Load()
 
 		// Run interactive list
+		loadKeyBindings()
 		m := newLsModel(idx, store)
-		p := tea.NewProgram(m, tea.WithAltScreen())
-
-		finalModel, err := p.Run()
+		finalModel, err := runProgram(m, tea.WithAltScreen())
 		if err != nil {
 			return fmt.Errorf("error running list: %w", err)
 		}
 
 		// Check if user selected an app to run
 		if model, ok := finalModel.(*lsModel); ok && model.runApp != "" {
-			return runInstalledApp(model.runApp, nil, false, nil)
+			return runInstalledApp(model.runApp, "", nil, false, nil)
 		}
 
 		return nil
@@ -63,6 +63,7 @@ type lsView int
 const (
 	lsViewList lsView = iota
 	lsViewDetail
+	lsViewConfirmDelete
 )
 
 // lsModel is the bubbletea model for the ls command
@@ -74,9 +75,20 @@ type lsModel struct {
 	selectedItem *lsItem
 	detailCursor int // 0 = Run, 1 = Delete
 	runApp       string
-	width        int
-	height       int
-	err          error
+	keys         tui.KeyMap
+
+	validating     bool // an app directory check is running; its result replaces the items
+	pendingGoToEnd bool // G/end pressed during the check; follow to the bottom after it
+
+	// Delete confirmation state
+	confirmCursor    int   // 0 = Yes, 1 = No
+	deleteSize       int64 // -1 while calculating
+	deleteSizeErr    error
+	deleteHasSession bool
+
+	width  int
+	height int
+	err    error
 }
 
 // lsItem represents an app in the list
@@ -89,6 +101,18 @@ type lsItem struct {
 	missing     bool
 }
 
+// lsDiskUsageMsg reports the size of an app directory pending deletion
+type lsDiskUsageMsg struct {
+	key  string
+	size int64
+	err  error
+}
+
+// lsValidatedMsg reports which app directories exist
+type lsValidatedMsg struct {
+	exists map[string]bool
+}
+
 func (i lsItem) Title() string {
 	title := i.name
 	if i.author != "" {
@@ -134,6 +158,7 @@ func newLsModel(idx *appindex.Index, store *sessions.Store) *lsModel {
 		index:       idx,
 		sessions:    store,
 		currentView: lsViewList,
+		keys:        tui.DefaultKeyMap(),
 	}
 
 	m.loadItems()
@@ -143,9 +168,6 @@ func newLsModel(idx *appindex.Index, store *sessions.Store) *lsModel {
 
 func (m *lsModel) loadItems() {
 	keys := m.index.List()
-	sort.Strings(keys)
-
-	exists := m.index.ValidateFilesystem()
 
 	items := make([]list.Item, 0, len(keys))
 	for _, k := range keys {
@@ -153,10 +175,9 @@ func (m *lsModel) loadItems() {
 		author, name := splitAppKey(k)
 
 		item := lsItem{
-			key:     k,
-			name:    name,
-			author:  author,
-			missing: !exists[k],
+			key:    k,
+			name:   name,
+			author: author,
 		}
 
 		if entry != nil {
@@ -170,8 +191,42 @@ func (m *lsModel) loadItems() {
 	m.list.SetItems(items)
 }
 
+// Init checks app directories in the background so the list renders
+// immediately; missing apps are marked once the check finishes
 func (m *lsModel) Init() tea.Cmd {
-	return nil
+	return m.validate()
+}
+
+// validate checks which app directories exist, for applyValidation. The
+// paths are copied first, since deleting an app changes the index while the
+// check runs.
+func (m *lsModel) validate() tea.Cmd {
+	m.validating = true
+	paths := m.index.Paths()
+	return func() tea.Msg {
+		return lsValidatedMsg{exists: appindex.ValidatePaths(paths)}
+	}
+}
+
+// applyValidation marks the apps whose directory is gone
+func (m *lsModel) applyValidation(exists map[string]bool) {
+	items := m.list.Items()
+	for i, it := range items {
+		item, ok := it.(lsItem)
+		if !ok {
+			continue
+		}
+		if present, checked := exists[item.key]; checked {
+			item.missing = !present
+			items[i] = item
+		}
+	}
+	m.list.SetItems(items)
+	if m.selectedItem != nil {
+		if present, checked := exists[m.selectedItem.key]; checked {
+			m.selectedItem.missing = !present
+		}
+	}
 }
 
 func (m *lsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -182,9 +237,30 @@ func (m *lsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.list.SetSize(msg.Width, msg.Height)
 		return m, nil
 
+	case lsValidatedMsg:
+		m.validating = false
+		m.applyValidation(msg.exists)
+		if m.pendingGoToEnd {
+			m.pendingGoToEnd = false
+			if n := len(m.list.VisibleItems()); n > 0 {
+				m.list.Select(n - 1)
+			}
+		}
+		return m, nil
+
+	case lsDiskUsageMsg:
+		if m.selectedItem != nil && m.selectedItem.key == msg.key {
+			m.deleteSize = msg.size
+			m.deleteSizeErr = msg.err
+		}
+		return m, nil
+
 	case tea.KeyMsg:
-		if m.currentView == lsViewDetail {
+		switch m.currentView {
+		case lsViewDetail:
 			return m.updateDetailView(msg)
+		case lsViewConfirmDelete:
+			return m.updateConfirmDeleteView(msg)
 		}
 		return m.updateListView(msg)
 	}
@@ -202,6 +278,15 @@ func (m *lsModel) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	switch {
+	case key.Matches(msg, m.list.KeyMap.GoToEnd):
+		if m.validating {
+			m.pendingGoToEnd = true
+		}
+	case key.Matches(msg, m.list.KeyMap.GoToStart, m.list.KeyMap.PrevPage, m.list.KeyMap.CursorUp):
+		m.pendingGoToEnd = false
+	}
+
 	switch msg.String() {
 	case "q", "ctrl+c":
 		return m, tea.Quit
@@ -246,21 +331,64 @@ func (m *lsModel) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, tea.Quit
 			}
 		} else {
-			// Delete
-			if err := m.deleteApp(m.selectedItem.key); err != nil {
-				m.err = err
-				return m, nil
-			}
+			// Delete, after confirmation
 			m.err = nil
-			m.currentView = lsViewList
-			m.selectedItem = nil
-			m.loadItems()
+			m.currentView = lsViewConfirmDelete
+			m.confirmCursor = 1 // Default to No for safety
+			m.deleteSize = -1
+			m.deleteSizeErr = nil
+			m.deleteHasSession = false
+			if m.sessions != nil {
+				_, m.deleteHasSession = m.sessions.Get(m.selectedItem.key)
+			}
+			return m, measureDiskUsage(m.selectedItem.key)
+		}
+	}
+	return m, nil
+}
+
+func (m *lsModel) updateConfirmDeleteView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+	case key.Matches(msg, m.keys.Back):
+		m.currentView = lsViewDetail
+		return m, nil
+	case key.Matches(msg, m.keys.Left):
+		if m.confirmCursor > 0 {
+			m.confirmCursor--
+		}
+	case key.Matches(msg, m.keys.Right):
+		if m.confirmCursor < 1 {
+			m.confirmCursor++
+		}
+	case key.Matches(msg, m.keys.Enter):
+		if m.selectedItem == nil || m.confirmCursor == 1 {
+			m.currentView = lsViewDetail
+			return m, nil
+		}
+		if err := m.deleteApp(m.selectedItem.key); err != nil {
+			m.err = err
+			m.currentView = lsViewDetail
 			return m, nil
 		}
+		m.err = nil
+		m.currentView = lsViewList
+		m.selectedItem = nil
+		// Reloading clears the missing markers, so check again
+		m.loadItems()
+		return m, m.validate()
 	}
 	return m, nil
 }
 
+func measureDiskUsage(key string) tea.Cmd {
+	return func() tea.Msg {
+		size, err := appindex.DiskUsage(key)
+		return lsDiskUsageMsg{key: key, size: size, err: err}
+	}
+}
+
 func (m *lsModel) deleteApp(key string) error {
 	// Validate key format
 	parts := strings.SplitN(key, "/", 2)
@@ -269,7 +397,7 @@ func (m *lsModel) deleteApp(key string) error {
 	}
 
 	// Remove from filesystem
-	appPath := config.AppPath(parts[0], parts[1])
+	appPath := m.index.Path(key)
 	if err := os.RemoveAll(appPath); err != nil {
 		return fmt.Errorf("failed to remove app files: %w", err)
 	}
@@ -285,16 +413,77 @@ func (m *lsModel) deleteApp(key string) error {
 		_ = m.sessions.Delete(key)
 	}
 
+	_ = events.Record(events.Remove, key)
+
 	return nil
 }
 
 func (m *lsModel) View() string {
-	if m.currentView == lsViewDetail {
+	switch m.currentView {
+	case lsViewDetail:
 		return m.viewDetail()
+	case lsViewConfirmDelete:
+		return m.viewConfirmDelete()
 	}
 	return m.list.View()
 }
 
+func (m *lsModel) viewConfirmDelete() string {
+	if m.selectedItem == nil {
+		return ""
+	}
+
+	item := m.selectedItem
+	var b strings.Builder
+
+	b.WriteString("\n")
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+	b.WriteString("  ")
+	b.WriteString(titleStyle.Render("Delete " + item.key + "?"))
+	b.WriteString("\n\n")
+
+	// Disk usage
+	b.WriteString("  ")
+	switch {
+	case item.missing:
+		b.WriteString(styles.MutedStyle.Render("Directory is already missing"))
+	case m.deleteSizeErr != nil:
+		b.WriteString(styles.MutedStyle.Render("Disk usage unknown: " + m.deleteSizeErr.Error()))
+	case m.deleteSize < 0:
+		b.WriteString(styles.MutedStyle.Render("Calculating disk usage..."))
+	default:
+		b.WriteString("Frees " + lipgloss.NewStyle().Bold(true).Render(appindex.FormatSize(m.deleteSize)))
+	}
+	b.WriteString("\n")
+
+	// Session state
+	b.WriteString("  ")
+	if m.deleteHasSession {
+		b.WriteString(styles.WarningStyle.Render("Has a saved session that will be discarded"))
+	} else {
+		b.WriteString(styles.MutedStyle.Render("No saved session"))
+	}
+	b.WriteString("\n\n")
+
+	// Yes/No buttons
+	b.WriteString("  ")
+	b.WriteString(components.ConfirmButtons(m.confirmCursor))
+	b.WriteString("\n\n")
+
+	// Help
+	b.WriteString("  ")
+	b.WriteString(styles.MutedStyle.Render(tui.HelpLine(
+		tui.Combined("select", m.keys.Left, m.keys.Right),
+		tui.WithHelp(m.keys.Enter, "confirm"),
+		tui.WithHelp(m.keys.Back, "cancel"),
+		m.keys.Quit,
+	)))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
 func (m *lsModel) viewDetail() string {
 	if m.selectedItem == nil {
 		return ""
@@ -71,6 +71,10 @@ Example:
 			return fmt.Errorf("invalid format: expected org/repo (e.g., myorg/myapp)")
 		}
 
+		if err := requireGit("create apps"); err != nil {
+			return err
+		}
+
 		// Ensure working directory is initialized
 		if err := config.EnsureInitialized(); err != nil {
 			return fmt.Errorf("failed to initialize: %w", err)
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	kioskexec "github.com/reflective-technologies/kiosk-cli/internal/exec"
+	"github.com/spf13/cobra"
+)
+
+var openEditorFlag string
+
+var openCmd = &cobra.Command{
+	Use:   "open <org/repo>",
+	Short: "Open an installed app in your editor",
+	Long: `Open an installed app's directory in your editor.
+
+The editor is chosen from --editor, $VISUAL, $EDITOR, the 'editor' config
+setting, and finally a platform default (code, vim).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+
+		idx, err := appindex.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load app index: %w", err)
+		}
+		if !idx.Has(key) {
+			return fmt.Errorf("app %q is not installed", key)
+		}
+
+		parts := strings.SplitN(key, "/", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid app key: %s", key)
+		}
+		appPath := idx.Path(key)
+		if _, err := os.Stat(appPath); os.IsNotExist(err) {
+			return fmt.Errorf("app directory missing: %s (try removing and reinstalling)", appPath)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		editorCmd, terminal, err := kioskexec.EditorCmd(appPath, openEditorFlag, cfg.Editor)
+		if err != nil {
+			return err
+		}
+
+		if terminal {
+			return editorCmd.Run()
+		}
+		if err := editorCmd.Start(); err != nil {
+			return fmt.Errorf("failed to open editor: %w", err)
+		}
+		fmt.Printf("Opened %s\n", appPath)
+		return nil
+	},
+}
+
+func init() {
+	openCmd.Flags().StringVar(&openEditorFlag, "editor", "", "editor command to use (overrides $VISUAL/$EDITOR)")
+	rootCmd.AddCommand(openCmd)
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/api"
+	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	"github.com/reflective-technologies/kiosk-cli/internal/git"
+)
+
+// printAppPrompt prints the prompt `kiosk run` would start Claude with, for
+// --print-prompt. Installed apps get the run prompt, with update instructions
+// if an update is waiting; other apps get their install prompt from the API.
+func printAppPrompt(cfg *config.Config, idx *appindex.Index, appArg, key, workDir string) error {
+	if !idx.Has(key) {
+		client := api.NewClient(cfg.APIUrl).WithRegistry(cfg.Registry)
+		prompt, err := cachedAppFetch(cfg, "installPrompt", appArg, runRefreshFlag, func() (string, error) {
+			return client.GetInstallPrompt(appArg)
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Println(prompt)
+		return nil
+	}
+
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid app key: %s", key)
+	}
+	appPath := idx.Path(key)
+
+	prompt := appRunPrompt(key)
+	if appPinnedVersion(key) == "" {
+		if info := pendingUpdate(appPath); info != nil {
+			prompt = buildUpdatePrompt(info, prompt)
+		}
+	}
+	if workDir != "" && workDir != appPath {
+		prompt = workDirPrompt(appPath, workDir, prompt)
+	}
+
+	fmt.Println(prompt)
+	return nil
+}
+
+// pendingUpdate fetches the app's upstream and describes the update the next
+// run would pull, without applying it. It returns nil if the app is up to
+// date or can't be updated.
+func pendingUpdate(appPath string) *updateInfo {
+	if !git.Available() {
+		return nil
+	}
+	ctx, g := context.Background(), gitClient()
+
+	oldCommit, err := g.RevParse(ctx, appPath, "HEAD")
+	if err != nil {
+		return nil
+	}
+
+	if err := g.Fetch(ctx, appPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to fetch updates in %s: %v\n", appPath, err)
+		return nil
+	}
+
+	ahead, behind, ok := g.AheadBehind(ctx, appPath)
+	if !ok || behind == 0 || ahead > 0 {
+		return nil
+	}
+
+	newCommit, err := g.RevParse(ctx, appPath, "@{u}")
+	if err != nil {
+		return nil
+	}
+
+	status, _ := g.Status(ctx, appPath)
+	return &updateInfo{
+		updated:   true,
+		oldCommit: oldCommit,
+		newCommit: newCommit,
+		hadStash:  status != "",
+	}
+}
@@ -1,16 +1,20 @@
 package cmd
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/reflective-technologies/kiosk-cli/internal/api"
 	"github.com/reflective-technologies/kiosk-cli/internal/auth"
 	"github.com/reflective-technologies/kiosk-cli/internal/config"
 	kioskexec "github.com/reflective-technologies/kiosk-cli/internal/exec"
+	"github.com/reflective-technologies/kiosk-cli/internal/git"
+	"github.com/reflective-technologies/kiosk-cli/internal/kioskmd"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var publishCmd = &cobra.Command{
@@ -21,29 +25,31 @@ var publishCmd = &cobra.Command{
 Run this command from within a git repository that has a GitHub remote.
 Claude Code will guide you through the publishing process.
 
+For a repository with several apps, --subdirectory names the directory
+(relative to the repository root) that holds the app's KIOSK.md. The app is
+published from the current branch unless --branch says otherwise.
+
 Note: Run 'kiosk init' first to create a KIOSK.md file if you don't have one.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Check if audit flag is set
 		runAudit, _ := cmd.Flags().GetBool("audit")
+		cwdFlag, _ := cmd.Flags().GetString("cwd")
 		if runAudit {
-			cwd, err := os.Getwd()
+			cwd, err := resolveWorkDir(cwdFlag)
 			if err != nil {
-				return fmt.Errorf("failed to get current directory: %w", err)
+				return err
 			}
 
-			if err := execClaudeAudit(cwd, kioskexec.AuditPrompt); err != nil {
+			if err := execClaudeAudit(cwd, kioskexec.AuditPrompt(cwd)); err != nil {
 				return fmt.Errorf("audit failed: %w", err)
 			}
 
-			fmt.Print("\nContinue with publish? [y/N]: ")
-			reader := bufio.NewReader(os.Stdin)
-			response, err := reader.ReadString('\n')
+			fmt.Fprintln(os.Stderr)
+			ok, err := confirm("Continue with publish?")
 			if err != nil {
-				return fmt.Errorf("failed to read response: %w", err)
+				return err
 			}
-
-			response = strings.TrimSpace(strings.ToLower(response))
-			if response != "y" && response != "yes" {
+			if !ok {
 				fmt.Println("Publish cancelled.")
 				return nil
 			}
@@ -61,26 +67,53 @@ Note: Run 'kiosk init' first to create a KIOSK.md file if you don't have one.`,
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
-		// Get current working directory
-		cwd, err := os.Getwd()
+		// Get the directory to publish
+		cwd, err := resolveWorkDir(cwdFlag)
+		if err != nil {
+			return err
+		}
+
+		branch, _ := cmd.Flags().GetString("branch")
+		subdir, _ := cmd.Flags().GetString("subdirectory")
+		target, err := resolvePublishTarget(cwd, branch, subdir)
 		if err != nil {
-			return fmt.Errorf("failed to get current directory: %w", err)
+			return err
+		}
+		if subdir == "" && target.subdirectory != "" {
+			infof("Publishing the app in %s\n", target.subdirectory)
 		}
 
 		// Require KIOSK.md to publish
-		if !kioskMdExists(cwd) {
+		if !kioskmd.Exists(target.appDir) {
+			if target.subdirectory != "" {
+				return fmt.Errorf("no KIOSK.md found in %s. Run 'kiosk init' there first to create one", target.subdirectory)
+			}
 			return fmt.Errorf("no KIOSK.md found. Run 'kiosk init' first to create one")
 		}
 
+		force, _ := cmd.Flags().GetBool("force")
+		if err := confirmPublishState(cwd, force); err != nil {
+			return err
+		}
+
 		client := api.NewClient(cfg.APIUrl)
+		if err := client.Ping(); err != nil {
+			return err
+		}
 
 		// Fetch the publish prompt
-		fmt.Println("Fetching publish instructions...")
-		prompt, err := client.GetPublishPrompt()
+		var prompt string
+		err = withSpinner("Fetching publish instructions...", func() error {
+			var err error
+			prompt, err = client.GetPublishPrompt()
+			return err
+		})
 		if err != nil {
 			return err
 		}
 
+		prompt = target.prompt(prompt)
+
 		// Get safe flag
 		safe, _ := cmd.Flags().GetBool("safe")
 
@@ -90,19 +123,175 @@ Note: Run 'kiosk init' first to create a KIOSK.md file if you don't have one.`,
 	},
 }
 
-// kioskMdExists checks if a KIOSK.md file exists in the given directory
-func kioskMdExists(dir string) bool {
-	variants := []string{"KIOSK.md", "Kiosk.md", "kiosk.md"}
-	for _, name := range variants {
-		if _, err := os.Stat(dir + "/" + name); err == nil {
-			return true
+// publishTarget is the branch and directory of a repository an app is
+// published from
+type publishTarget struct {
+	branch       string // empty if HEAD is detached and none was given
+	subdirectory string // relative to the repository root; empty for the root
+	appDir       string // absolute path of the app's directory
+}
+
+// publishSearchDepth is how many levels below the working directory publish
+// looks for a KIOSK.md when there isn't one in it
+const publishSearchDepth = 2
+
+// resolvePublishTarget works out what to publish from dir. branch defaults
+// to the current branch. A non-empty subdir must be a directory inside the
+// repository; otherwise the app is the one whose KIOSK.md is in dir, or in
+// the only directory below it that has one.
+func resolvePublishTarget(dir, branch, subdir string) (*publishTarget, error) {
+	target := &publishTarget{branch: branch, appDir: dir}
+	if branch == "" {
+		if current, err := gitOutput(dir, "rev-parse", "--abbrev-ref", "HEAD"); err == nil && current != "HEAD" {
+			target.branch = current
 		}
+	} else if _, err := gitOutput(dir, "rev-parse", "--verify", "--quiet", branch); err != nil {
+		if _, err := gitOutput(dir, "rev-parse", "--verify", "--quiet", "origin/"+branch); err != nil {
+			return nil, fmt.Errorf("branch %q not found in %s", branch, dir)
+		}
+	}
+
+	if subdir == "" {
+		return inferPublishSubdirectory(target)
+	}
+	root, err := gitOutput(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("--subdirectory needs a git repository: %w", err)
+	}
+	appDir := filepath.Join(root, filepath.FromSlash(subdir))
+	rel, err := filepath.Rel(root, appDir)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("subdirectory %q must be inside the repository", subdir)
+	}
+	if info, err := os.Stat(appDir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("subdirectory %q not found in %s", subdir, root)
+	}
+	target.subdirectory = filepath.ToSlash(rel)
+	target.appDir = appDir
+	return target, nil
+}
+
+// inferPublishSubdirectory points target at the app's KIOSK.md when no
+// subdirectory was given, e.g. in a monorepo where it isn't at the root
+func inferPublishSubdirectory(target *publishTarget) (*publishTarget, error) {
+	if !kioskmd.Exists(target.appDir) {
+		switch found := kioskmd.Search(target.appDir, publishSearchDepth); len(found) {
+		case 0:
+			return target, nil
+		case 1:
+			target.appDir = filepath.Join(target.appDir, filepath.FromSlash(found[0]))
+		default:
+			return nil, fmt.Errorf("found KIOSK.md in several directories (%s); choose one with --subdirectory", strings.Join(found, ", "))
+		}
+	}
+
+	root, err := gitOutput(target.appDir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return target, nil
+	}
+	rel, err := relativeToRoot(root, target.appDir)
+	if err != nil || rel == "." {
+		return target, nil
+	}
+	target.subdirectory = filepath.ToSlash(rel)
+	return target, nil
+}
+
+// relativeToRoot returns dir relative to the repository root, resolving
+// symlinks first since git reports the root with them resolved
+func relativeToRoot(root, dir string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is not inside %s", dir, root)
+	}
+	return rel, nil
+}
+
+// prompt tells Claude which branch and subdirectory to publish, ahead of
+// the publish instructions
+func (t *publishTarget) prompt(prompt string) string {
+	var b strings.Builder
+	if t.branch != "" {
+		fmt.Fprintf(&b, "Publish the app from the %q branch: set \"branch\": %q in the create request.\n", t.branch, t.branch)
+	}
+	if t.subdirectory != "" {
+		fmt.Fprintf(&b, "The app lives in the %q subdirectory of this repository, which holds its KIOSK.md: set \"subdirectory\": %q in the create request.\n", t.subdirectory, t.subdirectory)
+	}
+	b.WriteString(prompt)
+	return b.String()
+}
+
+// unpublishedChanges describes local work in dir that won't be part of the
+// published app: uncommitted changes, and commits not pushed upstream.
+// Directories git can't inspect report nothing and are left to Claude.
+func unpublishedChanges(dir string) []string {
+	status, err := gitOutput(dir, "status", "--porcelain")
+	if err != nil {
+		return nil
+	}
+
+	var problems []string
+	if status != "" {
+		problems = append(problems, fmt.Sprintf("uncommitted changes: %s", git.SummarizeStatus(status)))
+	}
+
+	branch, err := gitOutput(dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil || branch == "HEAD" {
+		return problems
+	}
+	if _, err := gitOutput(dir, "rev-parse", "--abbrev-ref", "@{upstream}"); err != nil {
+		return append(problems, fmt.Sprintf("branch %s has not been pushed (it has no upstream)", branch))
+	}
+	ahead, err := gitOutput(dir, "rev-list", "--count", "@{upstream}..HEAD")
+	if err == nil && ahead != "0" {
+		problems = append(problems, fmt.Sprintf("%s unpushed commit(s) on %s", ahead, branch))
+	}
+	return problems
+}
+
+// errPublishCancelled is returned when the user declines to publish
+var errPublishCancelled = errors.New("publish cancelled")
+
+// confirmPublishState warns when dir has work that won't be published and
+// asks whether to go on. Without a terminal to ask on it fails unless force
+// is set, and it fails if the user declines.
+func confirmPublishState(dir string, force bool) error {
+	problems := unpublishedChanges(dir)
+	if len(problems) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, "Publishing uses what's pushed to your remote, so this local work won't be included:")
+	for _, p := range problems {
+		fmt.Fprintf(os.Stderr, "  - %s\n", p)
+	}
+	if force {
+		return nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("refusing to publish with unpushed local work; commit and push it, or use --force")
+	}
+
+	ok, err := confirm("Publish anyway?")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errPublishCancelled
 	}
-	return false
+	return nil
 }
 
 func init() {
 	rootCmd.AddCommand(publishCmd)
 	publishCmd.Flags().Bool("safe", false, "Run Claude Code in safe mode (prompts for permissions)")
 	publishCmd.Flags().Bool("audit", false, "Run security audit before publishing")
+	publishCmd.Flags().String("cwd", "", "Publish this directory instead of the current directory")
+	publishCmd.Flags().Bool("force", false, "Publish even with uncommitted or unpushed changes")
+	publishCmd.Flags().String("branch", "", "Branch to publish (default: the current branch)")
+	publishCmd.Flags().String("subdirectory", "", "Directory in the repo that holds the app, for repos with several apps")
 }
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/requirements"
+)
+
+var skipRequirementsFlag bool
+
+// checkAppRequirements verifies the runtimes the app at appPath declares in
+// its KIOSK.md before Claude is launched, so a missing prerequisite is caught
+// up front instead of partway through a session. Unmet requirements are an
+// error unless --skip-requirements was given; a runtime kiosk doesn't know,
+// or whose version can't be determined, only produces a warning, written to
+// warn.
+func checkAppRequirements(appPath string, warn io.Writer) error {
+	reqs, err := requirements.ReadRequirements(appPath)
+	if err != nil {
+		fmt.Fprintf(warn, "Warning: %v; skipping runtime checks\n", err)
+		return nil
+	}
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	var unmet []string
+	for _, result := range requirements.Check(reqs) {
+		problem := result.Problem()
+		switch {
+		case problem == "":
+			continue
+		case result.Unknown, result.Found && result.Version == "":
+			fmt.Fprintf(warn, "Warning: %s\n", problem)
+		default:
+			unmet = append(unmet, problem)
+		}
+	}
+	if len(unmet) == 0 {
+		return nil
+	}
+
+	if skipRequirementsFlag {
+		for _, problem := range unmet {
+			fmt.Fprintf(warn, "Warning: %s\n", problem)
+		}
+		return nil
+	}
+	return fmt.Errorf("this app's requirements are not met:\n  %s\ninstall them and try again, or run with --skip-requirements to launch anyway", strings.Join(unmet, "\n  "))
+}
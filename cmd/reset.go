@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
+	"github.com/reflective-technologies/kiosk-cli/internal/auth"
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	"github.com/reflective-technologies/kiosk-cli/internal/git"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	resetCredentials bool
+	resetSessions    bool
+	resetCache       bool
+	resetApps        bool
+	resetAll         bool
+	resetForce       bool
+)
+
+// resetItem is one file or directory kiosk reset removes
+type resetItem struct {
+	desc string
+	path string
+}
+
+var resetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Remove kiosk's credentials, caches, and optionally apps",
+	Long: `Return ~/.kiosk to a clean state, e.g. before handing a machine on.
+
+With no flags, reset removes your credentials, saved sessions, and cached app
+lookups, and keeps installed apps. Pick what to remove with --credentials,
+--sessions, --cache, and --apps, or remove everything kiosk stores, including
+its config and activity log, with --all.
+
+--apps deletes only the apps kiosk installed, never anything else in the apps
+directory. Apps with uncommitted or unpushed changes are listed before you
+confirm, and --yes doesn't skip that confirmation unless --force is given too.
+Everything removed is printed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !resetCredentials && !resetSessions && !resetCache && !resetApps && !resetAll {
+			resetCredentials, resetSessions, resetCache = true, true, true
+		}
+		if resetAll {
+			resetCredentials, resetSessions, resetCache, resetApps = true, true, true, true
+		}
+
+		var items []resetItem
+		if resetCredentials {
+			items = append(items, resetItem{"credentials", auth.CredentialsPath()})
+		}
+		// Saved sessions belong to apps, so they go with them
+		if resetSessions || resetApps {
+			items = append(items, resetItem{"saved sessions", config.SessionsPath()})
+		}
+		if resetCache {
+			items = append(items, resetItem{"cached app lookups", config.CacheDir()})
+		}
+
+		// Read before --all removes the config that may set it
+		appsDir := config.AppsDir()
+
+		var unsaved []string
+		if resetApps {
+			idx, err := appindex.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load app index: %w", err)
+			}
+			for _, key := range idx.List() {
+				appPath := idx.Path(key)
+				items = append(items, resetItem{"app " + key, appPath})
+				if git.Available() {
+					if work := gitClient().UnsavedWork(context.Background(), appPath); len(work) > 0 {
+						unsaved = append(unsaved, fmt.Sprintf("%s: %s", key, strings.Join(work, "; ")))
+					}
+				}
+			}
+			items = append(items, resetItem{"app index", appindex.IndexPath()})
+		}
+		if resetAll {
+			items = append(items,
+				resetItem{"activity log", config.EventsPath()},
+				resetItem{"config", config.ConfigPath()},
+			)
+			crashLogs, _ := filepath.Glob(filepath.Join(config.KioskDir(), "crash-*.log"))
+			for _, path := range crashLogs {
+				items = append(items, resetItem{"crash report", path})
+			}
+		}
+
+		// Only mention what is actually there
+		existing := items[:0]
+		for _, item := range items {
+			if _, err := os.Lstat(item.path); err == nil {
+				existing = append(existing, item)
+			}
+		}
+		items = existing
+		if len(items) == 0 {
+			fmt.Println("Nothing to remove.")
+			return nil
+		}
+
+		fmt.Println("This will remove:")
+		for _, item := range items {
+			fmt.Printf("  %s (%s)\n", item.desc, item.path)
+		}
+		if len(unsaved) > 0 {
+			fmt.Fprintln(os.Stderr, "Warning: these apps have local work that isn't on their remote:")
+			for _, u := range unsaved {
+				fmt.Fprintf(os.Stderr, "  - %s\n", u)
+			}
+		}
+
+		// --yes alone never deletes unsaved work
+		if !assumeYes || (len(unsaved) > 0 && !resetForce) {
+			if assumeYes && !term.IsTerminal(int(os.Stdin.Fd())) {
+				return fmt.Errorf("refusing to remove apps with unsaved work; pass --force to remove them anyway")
+			}
+			ok, err := confirm("Continue?")
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("reset cancelled; nothing was removed")
+			}
+		}
+
+		var failed int
+		for _, item := range items {
+			if err := os.RemoveAll(item.path); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to remove %s: %v\n", item.desc, err)
+				failed++
+				continue
+			}
+			fmt.Printf("Removed %s (%s)\n", item.desc, item.path)
+		}
+		if resetApps {
+			removeEmptyOrgDirs(appsDir)
+		}
+		if resetAll {
+			// Leaves ~/.kiosk in place if anything unknown is still in it
+			_ = os.Remove(config.DefaultAppsDir())
+			_ = os.Remove(config.KioskDir())
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("failed to remove %d of %d items", failed, len(items))
+		}
+		return nil
+	},
+}
+
+// removeEmptyOrgDirs removes org directories in appsDir that removing
+// their apps left empty
+func removeEmptyOrgDirs(appsDir string) {
+	entries, err := os.ReadDir(appsDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			_ = os.Remove(filepath.Join(appsDir, entry.Name())) // fails unless empty
+		}
+	}
+}
+
+func init() {
+	resetCmd.Flags().BoolVar(&resetCredentials, "credentials", false, "remove stored credentials")
+	resetCmd.Flags().BoolVar(&resetSessions, "sessions", false, "remove saved app sessions")
+	resetCmd.Flags().BoolVar(&resetCache, "cache", false, "remove cached app lookups")
+	resetCmd.Flags().BoolVar(&resetApps, "apps", false, "remove installed apps, their sessions, and the app index")
+	resetCmd.Flags().BoolVar(&resetAll, "all", false, "remove everything kiosk stores, including its config")
+	resetCmd.Flags().BoolVar(&resetForce, "force", false, "with --yes, also remove apps that have unsaved work")
+	rootCmd.AddCommand(resetCmd)
+}
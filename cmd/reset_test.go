@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+)
+
+func TestResetAllLeavesNoKioskDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".kiosk")
+	t.Setenv(config.EnvConfig, filepath.Join(dir, "config.json"))
+	if err := config.EnsureInitialized(); err != nil {
+		t.Fatal(err)
+	}
+
+	assumeYes, resetAll = true, true
+	defer func() { assumeYes, resetAll = false, false }()
+	if err := resetCmd.RunE(resetCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// An update check that finishes after the reset must not save its result
+	updateCheckActive = true
+	updateCheckResult = make(chan string, 1)
+	updateCheckResult <- "v99.0.0"
+	defer func() { updateCheckActive, updateCheckResult = false, nil }()
+	finishUpdateCheck()
+
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("%s still exists after reset --all (stat error = %v)", dir, err)
+	}
+}
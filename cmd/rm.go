@@ -1,14 +1,15 @@
 package cmd
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
-	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	"github.com/reflective-technologies/kiosk-cli/internal/events"
+	"github.com/reflective-technologies/kiosk-cli/internal/git"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var rmForce bool
@@ -31,24 +32,37 @@ var rmCmd = &cobra.Command{
 			return fmt.Errorf("app %q is not installed", key)
 		}
 
-		// Confirm unless --force
-		if !rmForce {
-			fmt.Printf("Remove %q? This will delete the local copy. [y/N] ", key)
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.TrimSpace(strings.ToLower(response))
-			if response != "y" && response != "yes" {
-				fmt.Println("Cancelled.")
-				return nil
+		appPath := idx.Path(key)
+
+		// Local work would be lost for good, so --yes alone doesn't skip
+		// the prompt when there is any; only --force does
+		var unsaved []string
+		if !rmForce && git.Available() {
+			unsaved = gitClient().UnsavedWork(context.Background(), appPath)
+		}
+		if len(unsaved) > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %s has local work that isn't on its remote:\n", key)
+			for _, w := range unsaved {
+				fmt.Fprintf(os.Stderr, "  - %s\n", w)
 			}
 		}
 
-		// Remove directory if it exists
-		appPath := config.AppPath(key, "")
-		// Parse org/repo properly
-		parts := strings.SplitN(key, "/", 2)
-		if len(parts) == 2 {
-			appPath = config.AppPath(parts[0], parts[1])
+		// Confirm unless --force or --yes
+		if !rmForce && (!assumeYes || len(unsaved) > 0) {
+			if assumeYes && !term.IsTerminal(int(os.Stdin.Fd())) {
+				return fmt.Errorf("refusing to remove %s with unsaved work; pass --force to remove it anyway", key)
+			}
+			question := fmt.Sprintf("Remove %q? This will delete the local copy.", key)
+			if len(unsaved) > 0 {
+				question = fmt.Sprintf("Remove %q and lose these changes?", key)
+			}
+			ok, err := confirm(question)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("remove cancelled; %s was left as is", key)
+			}
 		}
 
 		if _, err := os.Stat(appPath); err == nil {
@@ -63,12 +77,14 @@ var rmCmd = &cobra.Command{
 			return fmt.Errorf("failed to save app index: %w", err)
 		}
 
+		_ = events.Record(events.Remove, key)
+
 		fmt.Printf("Removed %s\n", key)
 		return nil
 	},
 }
 
 func init() {
-	rmCmd.Flags().BoolVarP(&rmForce, "force", "f", false, "Skip confirmation prompt")
+	rmCmd.Flags().BoolVarP(&rmForce, "force", "f", false, "Skip confirmation, even if the app has uncommitted or unpushed changes")
 	rootCmd.AddCommand(rmCmd)
 }
@@ -3,10 +3,14 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 
+	"github.com/reflective-technologies/kiosk-cli/internal/api"
 	"github.com/reflective-technologies/kiosk-cli/internal/clistyle"
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
 	"github.com/reflective-technologies/kiosk-cli/internal/errors"
+	kioskexec "github.com/reflective-technologies/kiosk-cli/internal/exec"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -24,21 +28,97 @@ var rootCmd = &cobra.Command{
 	},
 }
 
+// quiet suppresses informational output such as progress messages and the
+// logo. Errors are still reported on stderr.
+var quiet bool
+
+// assumeYes answers yes to confirmation prompts for destructive commands
+// such as rm and logout. It doesn't change anything else they do.
+var assumeYes bool
+
+// configFile is an alternate config file set with --config
+var configFile string
+
+// registryFlag is an app metadata mirror set with --registry
+var registryFlag string
+
+// infof prints an informational message unless --quiet is set
+func infof(format string, a ...any) {
+	if quiet {
+		return
+	}
+	fmt.Printf(format, a...)
+}
+
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		errors.PrintError(err)
-		os.Exit(1)
+	defer handleCrash(saveTerminalState())
+
+	recoverInterruptedUpdate()
+	sweepStaleUpdateDirs()
+
+	err := rootCmd.Execute()
+	printConfigWarnings()
+	finishUpdateCheck()
+	if err != nil {
+		// A program that exited unsuccessfully has already said why
+		if _, ok := errors.IsExitError(err); !ok {
+			errors.PrintError(err)
+		}
+		os.Exit(errors.ExitCode(err))
+	}
+}
+
+// printConfigWarnings shows warnings about kiosk's own files, e.g. a corrupt
+// app index that was set aside while the command ran
+func printConfigWarnings() {
+	for _, w := range config.TakeWarnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
 	}
 }
 
 func init() {
 	// Enable verbose error logging in dev mode
 	errors.DevMode = Version == "dev"
+	// Log API schema drift only when asked to
+	api.Debug = os.Getenv(config.EnvDebug) != ""
+
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "suppress non-essential output")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "assume yes for confirmation prompts (rm, reset, logout, api update, run)")
+	rootCmd.PersistentFlags().StringVar(&registryFlag, "registry", "", "read app listings from this mirror (an API URL or file:// directory) instead of the Kiosk API")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file to use instead of ~/.kiosk/config.json (its directory holds all kiosk state)")
+
+	cobra.OnInitialize(func() {
+		if configFile != "" {
+			config.SetConfigPath(configFile)
+		}
+		if registryFlag != "" {
+			config.SetRegistry(registryFlag)
+		}
+		kioskexec.SetClaudePath(configuredClaudePath())
+		startUpdateCheck()
+	})
 
 	// Custom help function
 	rootCmd.SetHelpFunc(styledHelp)
 }
 
+// configuredClaudePath returns the claude binary named by
+// $KIOSK_CLAUDE_PATH or the claudePath setting, or "" to look claude up. A
+// relative $KIOSK_CLAUDE_PATH is resolved against the current directory
+// now, like --claude-path, since claude runs from the app's directory.
+func configuredClaudePath() string {
+	if path := os.Getenv(config.EnvClaudePath); path != "" {
+		if abs, err := filepath.Abs(path); err == nil {
+			return abs
+		}
+		return path
+	}
+	if cfg, err := config.Load(); err == nil {
+		return cfg.ClaudePath
+	}
+	return ""
+}
+
 // styledHelp renders a styled help output
 func styledHelp(cmd *cobra.Command, args []string) {
 	// Collect commands (excluding hidden ones)
@@ -1,13 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
-	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -15,15 +17,44 @@ import (
 	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
 	"github.com/reflective-technologies/kiosk-cli/internal/claude"
 	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	"github.com/reflective-technologies/kiosk-cli/internal/dotenv"
+	kioskerrors "github.com/reflective-technologies/kiosk-cli/internal/errors"
+	"github.com/reflective-technologies/kiosk-cli/internal/events"
 	kioskexec "github.com/reflective-technologies/kiosk-cli/internal/exec"
+	"github.com/reflective-technologies/kiosk-cli/internal/git"
 	"github.com/reflective-technologies/kiosk-cli/internal/giturl"
+	"github.com/reflective-technologies/kiosk-cli/internal/kioskmd"
 	"github.com/reflective-technologies/kiosk-cli/internal/sessions"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var sandboxFlag string
+var noSandboxFlag bool
 var safeFlag bool
+var runCwdFlag string
+var runDetachFlag bool
+var runResumeFlag bool
+var noChangelogFlag bool
+var runEnvFileFlag string
+var printPromptFlag bool
+var clearSandboxFlag bool
+var runShellFlag bool
+var runDepthFlag int
+var runRefreshFlag bool
+var runOutputDirFlag string
+var runPromptFlag string
+var runForceFlag bool
+var claudePathFlag string
+var runSkipFlag bool
+
+// runDepthSet is whether --depth was given, since 0 is a valid depth
+var runDepthSet bool
+
+// claudeEnv holds variables loaded with --env-file, added to the environment
+// of the claude process
+var claudeEnv []dotenv.Var
 
 const runPrompt = `Run the app in this directory. Check KIOSK.md for instructions on how to start and use this app.`
 
@@ -35,10 +66,54 @@ it will be fetched and installed first.
 
 The app can be specified as:
   - org/repo (e.g., anthropic/claude-starter)
-  - appId (e.g., claude-starter)`,
+  - appId (e.g., claude-starter)
+
+Append @<tag> to pin the app to a git tag (e.g., anthropic/claude-starter@v1.2.0).
+A pinned app is not updated automatically; run it with @latest to unpin it.
+If an update has to stash the app's local changes, you're asked first; --yes
+goes ahead without asking, and declining stops the run.
+
+Use --cwd to have an installed app work on another directory, such as your
+current project, instead of its install directory.
+
+Use --output-dir to install an app somewhere other than the apps directory,
+e.g. to develop it locally. kiosk remembers the location, so later runs and
+kiosk rm use it; rm deletes that directory.
+
+If the app's name is already installed from another repository, e.g. a
+different org's app or a repo that has moved, you're asked whether to replace
+it. --force replaces it without asking and --skip keeps and runs it.
+
+With --detach, press ctrl+k to end the session: Claude is stopped, but its
+conversation is saved, and --resume picks it up where it left off.
+
+Use --shell to open your $SHELL in the app's directory instead of launching
+Claude; the app is cloned first if it isn't installed.
+
+Use --print-prompt to see the prompt Claude would be started with, including
+any update instructions, without launching it. Nothing is installed or updated.
+
+Use --prompt to give the app a task and run Claude non-interactively,
+printing its reply; --prompt - reads the task from stdin, e.g.
+  echo "fix the failing test" | kiosk run org/repo --prompt - --quiet
+Claude gets no stdin in this mode, so it can't ask questions.
+
+An app that isn't installed yet is looked up on kiosk.app, and the result is
+reused for 10 minutes; pass --refresh to look it up again.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		appArg := args[0]
+		appArg, version := splitAppVersion(args[0])
+		if claudePathFlag != "" {
+			path, err := filepath.Abs(claudePathFlag)
+			if err != nil {
+				return fmt.Errorf("failed to resolve %s: %w", claudePathFlag, err)
+			}
+			kioskexec.SetClaudePath(path)
+		}
+		runDepthSet = cmd.Flags().Changed("depth")
+		if runDepthSet && runDepthFlag < 0 {
+			return fmt.Errorf("--depth must be 0 (full history) or more")
+		}
 
 		// Parse and transform sandbox values
 		sandboxValues, err := parseSandboxValues(sandboxFlag)
@@ -66,19 +141,94 @@ The app can be specified as:
 		// Normalize key to org/repo format for index lookup
 		key := normalizeAppKey(appArg)
 
+		var workDir string
+		if runCwdFlag != "" {
+			if workDir, err = resolveWorkDir(runCwdFlag); err != nil {
+				return err
+			}
+		}
+
+		if printPromptFlag {
+			return printAppPrompt(cfg, idx, appArg, key, workDir)
+		}
+
+		if runPromptFlag == "-" {
+			if runPromptFlag, err = readStdinPrompt(); err != nil {
+				return err
+			}
+		}
+
+		if runEnvFileFlag != "" {
+			if claudeEnv, err = dotenv.Load(runEnvFileFlag); err != nil {
+				return err
+			}
+			// Only the count: values are often secrets
+			infof("Loaded %d variables from %s\n", len(claudeEnv), runEnvFileFlag)
+		}
+
+		var sessionCfg *claudeSessionConfig
+		if runDetachFlag || runResumeFlag {
+			store, err := sessions.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load session store: %w", err)
+			}
+			if runResumeFlag {
+				if _, ok := store.Get(key); !ok || !idx.Has(key) {
+					return fmt.Errorf("no saved session for %s; start one with 'kiosk run --detach %s'", appArg, appArg)
+				}
+			}
+			sessionCfg = &claudeSessionConfig{Store: store}
+		}
+
+		if runOutputDirFlag != "" {
+			if runOutputDirFlag, err = filepath.Abs(runOutputDirFlag); err != nil {
+				return fmt.Errorf("failed to resolve %s: %w", runOutputDirFlag, err)
+			}
+			if idx.Has(key) && idx.Path(key) != runOutputDirFlag {
+				return fmt.Errorf("%s is already installed in %s; remove it with 'kiosk rm %s' to install it elsewhere", key, idx.Path(key), key)
+			}
+		}
+
 		// Check if app is installed
 		if idx.Has(key) {
-			return runInstalledApp(key, sandboxValues, safeFlag, nil)
+			if clearSandboxFlag {
+				if err := clearAppSandbox(key); err != nil {
+					return err
+				}
+			}
+			if version != "" {
+				if err := setAppVersion(idx, key, version); err != nil {
+					return err
+				}
+			}
+			return detachedOK(runInstalledApp(key, workDir, sandboxValues, safeFlag, sessionCfg), appArg)
+		}
+
+		// Installation has to happen in the app's own directory
+		if workDir != "" {
+			return fmt.Errorf("%s is not installed; run 'kiosk run %s' once without --cwd to install it", appArg, appArg)
 		}
 
 		// App not installed - fetch from API and install
-		return installAndRunApp(cfg, idx, appArg, key, sandboxValues, safeFlag, nil)
+		return detachedOK(installAndRunApp(cfg, idx, appArg, key, version, sandboxValues, safeFlag, sessionCfg), appArg)
 	},
 }
 
+// detachedOK treats detaching from a session as success and tells the user
+// how to get back to it
+func detachedOK(err error, appArg string) error {
+	if !errors.Is(err, claude.ErrDetached) {
+		return err
+	}
+	infof("\nSession saved. Resume it with 'kiosk run --resume %s'.\n", appArg)
+	return nil
+}
+
 // normalizeAppKey ensures we have an org/repo format for the index
-// If only appId is provided, we'll update this after fetching from API
+// If only appId is provided, we'll update this after fetching from API.
+// Any @version suffix is dropped.
 func normalizeAppKey(input string) string {
+	input, _ = splitAppVersion(input)
 	// If already has slash, assume it's org/repo
 	if strings.Contains(input, "/") {
 		return input
@@ -87,61 +237,178 @@ func normalizeAppKey(input string) string {
 	return input
 }
 
-// runInstalledApp runs an already-installed app
-func runInstalledApp(key string, sandboxValues []string, safe bool, sessionCfg *claudeSessionConfig) error {
+// resolveWorkDir returns dir as an absolute path, or the current directory
+// when dir is empty. It fails if the path isn't an existing directory.
+func resolveWorkDir(dir string) (string, error) {
+	if dir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("failed to get current directory: %w", err)
+		}
+		return cwd, nil
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("--cwd %s does not exist", dir)
+		}
+		return "", fmt.Errorf("failed to check %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("--cwd %s is not a directory", dir)
+	}
+	return abs, nil
+}
+
+// claudeInstallHint explains how to install Claude Code
+const claudeInstallHint = `Kiosk apps run inside Claude Code. Install it with:
+  npm install -g @anthropic-ai/claude-code
+
+See https://docs.claude.com/en/docs/claude-code/setup for other options.`
+
+// requireClaude returns a DependencyError if claude can't be run, or an
+// error if the claude binary kiosk was pointed at isn't executable
+func requireClaude() error {
+	if path := kioskexec.ClaudePath(); path != "" {
+		return kioskexec.CheckClaudePath(path)
+	}
+	if kioskexec.ClaudeAvailable() {
+		return nil
+	}
+	return kioskerrors.NewDependencyError("claude", claudeInstallHint)
+}
+
+// runInstalledApp runs an already-installed app. Claude runs in workDir when
+// set, and in the app's install directory otherwise.
+func runInstalledApp(key, workDir string, sandboxValues []string, safe bool, sessionCfg *claudeSessionConfig) error {
+	if !runShellFlag {
+		if err := requireClaude(); err != nil {
+			return err
+		}
+	}
+
 	parts := strings.SplitN(key, "/", 2)
 	if len(parts) != 2 {
 		return fmt.Errorf("invalid app key: %s", key)
 	}
 
-	appPath := config.AppPath(parts[0], parts[1])
+	appPath := appindex.Path(key)
 
 	// Verify directory exists
 	if _, err := os.Stat(appPath); os.IsNotExist(err) {
 		return fmt.Errorf("app directory missing: %s (try removing and reinstalling)", appPath)
 	}
 
-	prompt := runPrompt
-	updateInfo, err := updateRepoIfNeeded(appPath)
-	if err != nil {
-		return err
+	if runDepthSet && runDepthFlag == 0 {
+		if err := fetchFullHistory(key, appPath); err != nil {
+			return err
+		}
+	}
+
+	// The shell skips updating, so the next run still gets update instructions
+	if runShellFlag {
+		return openAppShell(appPath)
+	}
+
+	basePrompt := appRunPrompt(key)
+	prompt := basePrompt
+	var updateInfo *updateInfo
+	if pinned := appPinnedVersion(key); pinned != "" {
+		infof("%s is pinned to %s; run %s@latest to update it\n", key, pinned, key)
+	} else {
+		var confirm func(string) (bool, error)
+		if !assumeYes && term.IsTerminal(int(os.Stdin.Fd())) {
+			confirm = confirmStash
+		}
+		var err error
+		updateInfo, err = updateRepoIfNeeded(appPath, confirm)
+		if errors.Is(err, errUpdateDeclined) {
+			return fmt.Errorf("run cancelled; %s and its local changes were left as they are", key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if updateInfo != nil && updateInfo.unstashConflicts {
+		if err := resolveStashConflicts(appPath, updateInfo, sessionCfg); err != nil {
+			return err
+		}
 	}
 
 	if updateInfo != nil && updateInfo.updated {
-		prompt = buildUpdatePrompt(updateInfo)
+		prompt = buildUpdatePrompt(updateInfo, basePrompt)
+		_ = events.Record(events.Update, key)
+		if !noChangelogFlag {
+			printChangelog(appPath, updateInfo)
+		}
+	}
+
+	if err := checkAppRequirements(appPath, os.Stderr); err != nil {
+		return err
 	}
 
-	// Apply sandbox settings if specified
-	if len(sandboxValues) > 0 {
-		fmt.Printf("Configuring sandbox mode...\n")
-		if err := writeSandboxSettings(appPath, sandboxValues); err != nil {
-			return fmt.Errorf("failed to configure sandbox: %w", err)
+	// Claude runs elsewhere with --cwd, out of reach of the app's settings
+	if workDir == "" {
+		if err := applySandbox(appPath, sandboxValues, os.Stdout); err != nil {
+			return err
 		}
 	}
 
-	fmt.Printf("Running %s...\n", key)
-	fmt.Print(logo)
-	fmt.Print(lipgloss.NewStyle().Foreground(styles.Primary).Render(`  ┌───┐
+	dir := appPath
+	if workDir != "" && workDir != appPath {
+		dir = workDir
+		prompt = workDirPrompt(appPath, workDir, prompt)
+	}
+
+	_ = events.Record(events.Run, key)
+
+	infof("Running %s...\n", key)
+	infof("%s", logo)
+	infof("%s", lipgloss.NewStyle().Foreground(styles.Primary).Render(`  ┌───┐
  ┌┴───┴┐`))
 
-	return execClaudeSession(appPath, prompt, safe, key, sessionCfg)
+	return execClaudeSession(dir, prompt, safe, key, sessionCfg)
 }
 
 // installAndRunApp fetches an app from the API and installs it
-func installAndRunApp(cfg *config.Config, idx *appindex.Index, appArg, key string, sandboxValues []string, safe bool, sessionCfg *claudeSessionConfig) error {
-	client := api.NewClient(cfg.APIUrl)
-
-	// Fetch app metadata
-	fmt.Printf("Fetching %s...\n", appArg)
-	app, err := client.GetApp(appArg)
-	if err != nil {
+// A non-empty version pins the app to that git tag.
+func installAndRunApp(cfg *config.Config, idx *appindex.Index, appArg, key, version string, sandboxValues []string, safe bool, sessionCfg *claudeSessionConfig) error {
+	// Check before fetching or cloning anything so missing tools fail fast
+	if err := requireGit("install apps"); err != nil {
 		return err
 	}
+	if !runShellFlag {
+		if err := requireClaude(); err != nil {
+			return err
+		}
+	}
 
-	// Get installation prompt
-	prompt, err := client.GetInstallPrompt(appArg)
-	if err != nil {
+	client := api.NewClient(cfg.APIUrl).WithRegistry(cfg.Registry)
+
+	// Fetch app metadata and the installation prompt
+	var app *api.App
+	var prompt string
+	err := withSpinner(fmt.Sprintf("Fetching %s...", appArg), func() error {
+		var err error
+		app, err = cachedAppFetch(cfg, "app", appArg, runRefreshFlag, func() (*api.App, error) {
+			return client.GetApp(appArg)
+		})
+		if err != nil {
+			return err
+		}
+		prompt, err = cachedAppFetch(cfg, "installPrompt", appArg, runRefreshFlag, func() (string, error) {
+			return client.GetInstallPrompt(appArg)
+		})
 		return err
+	})
+	if err != nil {
+		return withAppSuggestions(client, appArg, err)
 	}
 
 	// Determine the key (org/repo) from git URL if we only had appId
@@ -157,45 +424,234 @@ func installAndRunApp(cfg *config.Config, idx *appindex.Index, appArg, key strin
 		return fmt.Errorf("could not determine org/repo for app")
 	}
 
+	warnRepoNameCollision(idx, key)
+	if conflict := idx.Conflict(key, app.GitUrl); conflict != nil {
+		replace, err := resolveKeyConflict(conflict, idx.Path(key))
+		if err != nil {
+			return err
+		}
+		if !replace {
+			infof("Keeping %s from %s.\n", key, conflict.Existing.GitUrl)
+			return runInstalledApp(key, "", sandboxValues, safe, sessionCfg)
+		}
+		if err := os.RemoveAll(idx.Path(key)); err != nil {
+			return fmt.Errorf("failed to remove directory: %w", err)
+		}
+		idx.Remove(key)
+		if err := appindex.Save(idx); err != nil {
+			return fmt.Errorf("failed to save app index: %w", err)
+		}
+	}
+
 	appPath := config.AppPath(parts[0], parts[1])
+	// Only recorded for apps outside the apps directory
+	var customPath string
+	if runOutputDirFlag != "" {
+		appPath, customPath = runOutputDirFlag, runOutputDirFlag
+	}
 
 	parentDir := filepath.Dir(appPath)
 	if err := os.MkdirAll(parentDir, 0755); err != nil {
 		return fmt.Errorf("failed to create app parent directory: %w", err)
 	}
 
-	if _, err := os.Stat(appPath); err == nil {
-		return fmt.Errorf("app already exists at %s (try removing it first)", appPath)
-	} else if !os.IsNotExist(err) {
+	// An empty directory, e.g. left by an older failed clone, is cloned into
+	if info, err := os.Stat(appPath); err == nil && !git.IsEmptyDir(appPath) {
+		// The directory exists but the index doesn't know about it. If it's a
+		// clone of this app, re-register it instead of failing.
+		if !isCloneOf(appPath, app.GitUrl) {
+			return fmt.Errorf("app already exists at %s but is not a clone of %s; move it aside and run kiosk run again, set its origin remote to %s if it is a copy of this app, or install elsewhere with --output-dir", appPath, app.GitUrl, app.GitUrl)
+		}
+		if !kioskmd.Exists(appPath) {
+			return fmt.Errorf("the copy of %s at %s has no KIOSK.md, so it can't be re-registered (move it aside and run kiosk run again to reinstall)", key, appPath)
+		}
+		infof("Found existing copy of %s, re-registering...\n", key)
+		shallow, _ := gitOutput(appPath, "rev-parse", "--is-shallow-repository")
+		if conflict := idx.AddChecked(key, &appindex.AppEntry{
+			Name:        app.Name,
+			Description: app.Description,
+			GitUrl:      app.GitUrl,
+			InstalledAt: info.ModTime(), // when it was cloned, as near as we can tell
+			Shallow:     shallow == "true",
+			Path:        customPath,
+			APIUrl:      cfg.APIUrl,
+			Registry:    cfg.Registry,
+		}); conflict != nil {
+			return conflictError(conflict)
+		}
+		if err := appindex.Save(idx); err != nil {
+			return fmt.Errorf("failed to save app index: %w", err)
+		}
+		if version != "" {
+			if err := setAppVersion(idx, key, version); err != nil {
+				return err
+			}
+		}
+		return runInstalledApp(key, "", sandboxValues, safe, sessionCfg)
+	} else if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to check app path: %w", err)
 	}
 
-	fmt.Printf("Cloning %s...\n", app.GitUrl)
-	if err := cloneRepo(app.GitUrl, appPath); err != nil {
-		return err
+	depth := cloneDepth()
+	if version == latestVersion {
+		version = ""
 	}
 
-	// Apply sandbox settings if specified
-	if len(sandboxValues) > 0 {
-		fmt.Printf("Configuring sandbox mode...\n")
-		if err := writeSandboxSettings(appPath, sandboxValues); err != nil {
-			return fmt.Errorf("failed to configure sandbox: %w", err)
+	// A directory that was already there, e.g. an empty --output-dir, is
+	// emptied rather than removed if the install fails
+	_, statErr := os.Stat(appPath)
+	existed := statErr == nil
+	removeClone := func() { git.RemovePartialClone(appPath, existed) }
+
+	// Checks the fresh clone and adds it to the index, removing it if it
+	// can't be installed. It reports on out and warns on warn.
+	register := func(out, warn io.Writer) error {
+		if version != "" {
+			if err := checkoutVersion(appPath, version); err != nil {
+				removeClone()
+				return err
+			}
+		}
+
+		// The install prompt relies on KIOSK.md; without it Claude has nothing to go on
+		if !kioskmd.Exists(appPath) {
+			removeClone()
+			return fmt.Errorf("%s has no KIOSK.md, so it can't be installed (the app's author can create one with 'kiosk init')", app.GitUrl)
+		}
+
+		if !runShellFlag {
+			if err := checkAppRequirements(appPath, warn); err != nil {
+				removeClone()
+				return err
+			}
+		}
+
+		// The sandbox only applies to Claude, not a --shell
+		if !runShellFlag {
+			if err := applySandbox(appPath, sandboxValues, out); err != nil {
+				removeClone()
+				return err
+			}
+		}
+
+		// Register in index
+		if conflict := idx.AddChecked(key, &appindex.AppEntry{
+			Name:        app.Name,
+			Description: app.Description,
+			GitUrl:      app.GitUrl,
+			Version:     version,
+			Shallow:     depth > 0,
+			Path:        customPath,
+			APIUrl:      cfg.APIUrl,
+			Registry:    cfg.Registry,
+		}); conflict != nil {
+			removeClone()
+			return conflictError(conflict)
 		}
+		if err := appindex.Save(idx); err != nil {
+			return fmt.Errorf("failed to save app index: %w", err)
+		}
+		_ = events.Record(events.Install, key)
+		return nil
 	}
 
-	// Register in index
-	idx.Add(key, &appindex.AppEntry{
-		Name:        app.Name,
-		Description: app.Description,
-		GitUrl:      app.GitUrl,
-	})
+	if installTUIFlag {
+		return runInstallTUI(app, key, appPath, prompt, depth, register)
+	}
+
+	infof("Cloning %s...\n", app.GitUrl)
+	// ctrl+c stops the clone; Clone removes the partial checkout
+	cloneCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err = gitClient().Clone(cloneCtx, app.GitUrl, appPath, depth, os.Stderr)
+	stop()
+	if err != nil {
+		return err
+	}
+	if err := register(os.Stdout, os.Stderr); err != nil {
+		return err
+	}
+
+	if runShellFlag {
+		return openAppShell(appPath)
+	}
+
+	infof("Installing %s...\n", app.Name)
+	infof("%s", logo)
+	return execClaudeSession(appPath, prompt, safe, key, sessionCfg)
+}
+
+// fetchFullHistory deepens a shallow clone to its full history, for apps
+// that need it after being installed with the default depth
+func fetchFullHistory(key, appPath string) error {
+	idx, err := appindex.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load app index: %w", err)
+	}
+	entry := idx.Get(key)
+	if entry == nil || !entry.Shallow {
+		return nil
+	}
+
+	infof("Fetching the full history of %s...\n", key)
+	if err := gitClient().Fetch(context.Background(), appPath, "--unshallow"); err != nil {
+		return fmt.Errorf("failed to fetch full history: %w", err)
+	}
+	entry.Shallow = false
 	if err := appindex.Save(idx); err != nil {
 		return fmt.Errorf("failed to save app index: %w", err)
 	}
+	return nil
+}
 
-	fmt.Printf("Installing %s...\n", app.Name)
-	fmt.Print(logo)
-	return execClaudeSession(appPath, prompt, safe, key, sessionCfg)
+// warnRepoNameCollision warns when another org's app with the same repo
+// name as key is installed, since Kiosk resolves a bare repo name to one app
+func warnRepoNameCollision(idx *appindex.Index, key string) {
+	_, repo, _ := strings.Cut(key, "/")
+	for _, other := range idx.List() {
+		_, otherRepo, _ := strings.Cut(other, "/")
+		if !strings.EqualFold(other, key) && strings.EqualFold(otherRepo, repo) {
+			fmt.Fprintf(os.Stderr, "Warning: %s is also installed; use the full org/repo to run either one\n", other)
+		}
+	}
+}
+
+// resolveKeyConflict decides what to do when key is already installed from
+// another repository, e.g. after an org collision or a moved repo. It reports
+// whether to replace the installed app; --force replaces it, --skip keeps it,
+// and otherwise the user is asked.
+func resolveKeyConflict(conflict *appindex.Conflict, appPath string) (bool, error) {
+	if runForceFlag {
+		return true, nil
+	}
+	if runSkipFlag {
+		return false, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, conflictError(conflict)
+	}
+
+	fmt.Fprintf(os.Stderr, "%s is already installed from %s, not %s.\n", conflict.Key, conflict.Existing.GitUrl, conflict.GitUrl)
+	if git.Available() {
+		if work := gitClient().UnsavedWork(context.Background(), appPath); len(work) > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: the installed copy has local work that isn't on its remote:\n")
+			for _, w := range work {
+				fmt.Fprintf(os.Stderr, "  - %s\n", w)
+			}
+		}
+	}
+	ok, err := confirm(fmt.Sprintf("Replace it with %s?", conflict.GitUrl))
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, fmt.Errorf("install cancelled; %s was left as is", conflict.Key)
+	}
+	return true, nil
+}
+
+// conflictError describes a key conflict that wasn't resolved
+func conflictError(conflict *appindex.Conflict) error {
+	return fmt.Errorf("%s is already installed from %s; not replacing it with %s (pass --force to replace it or --skip to keep it)", conflict.Key, conflict.Existing.GitUrl, conflict.GitUrl)
 }
 
 type updateInfo struct {
@@ -204,48 +660,48 @@ type updateInfo struct {
 	newCommit        string
 	hadStash         bool
 	unstashConflicts bool
+	stashDropped     bool // the user dropped the conflicted stash before launch
 }
 
-func updateRepoIfNeeded(appPath string) (*updateInfo, error) {
-	if _, err := exec.LookPath("git"); err != nil {
-		return nil, nil
-	}
-
-	inside, err := gitOutput(appPath, "rev-parse", "--is-inside-work-tree")
-	if err != nil || inside != "true" {
-		return nil, nil
-	}
+// errUpdateDeclined is returned by updateRepoIfNeeded when the user chose
+// not to stash their local changes
+var errUpdateDeclined = errors.New("update declined")
 
-	oldCommit, err := gitOutput(appPath, "rev-parse", "HEAD")
-	if err != nil {
+// updateRepoIfNeeded fast-forwards the app to its upstream branch, stashing
+// and re-applying any local changes around the pull. If confirm is non-nil
+// it is asked before stashing, with the git status of the changes.
+func updateRepoIfNeeded(appPath string, confirm func(status string) (bool, error)) (*updateInfo, error) {
+	if !git.Available() {
 		return nil, nil
 	}
+	return updateRepo(context.Background(), gitClient(), appPath, confirm)
+}
 
-	if err := gitRun(appPath, "fetch", "--quiet"); err != nil {
-		fmt.Printf("Warning: failed to fetch updates in %s: %v\n", appPath, err)
+// updateRepo is updateRepoIfNeeded with the git client to use
+func updateRepo(ctx context.Context, g *git.Git, appPath string, confirm func(status string) (bool, error)) (*updateInfo, error) {
+	inside, err := g.Output(ctx, appPath, "rev-parse", "--is-inside-work-tree")
+	if err != nil || inside != "true" {
 		return nil, nil
 	}
 
-	counts, err := gitOutput(appPath, "rev-list", "--left-right", "--count", "HEAD...@{u}")
+	oldCommit, err := g.RevParse(ctx, appPath, "HEAD")
 	if err != nil {
 		return nil, nil
 	}
 
-	parts := strings.Fields(counts)
-	if len(parts) != 2 {
-		return nil, nil
-	}
-
-	ahead, err := strconv.Atoi(parts[0])
-	if err != nil {
-		return nil, nil
-	}
-	behind, err := strconv.Atoi(parts[1])
-	if err != nil {
+	if err := g.Fetch(ctx, appPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to fetch updates in %s: %v\n", appPath, err)
 		return nil, nil
 	}
+	return pullFetched(ctx, g, appPath, oldCommit, confirm)
+}
 
-	if behind == 0 {
+// pullFetched is updateRepo after the fetch: it fast-forwards appPath from
+// oldCommit to the upstream commits already fetched, stashing local changes
+// around the pull
+func pullFetched(ctx context.Context, g *git.Git, appPath, oldCommit string, confirm func(status string) (bool, error)) (*updateInfo, error) {
+	ahead, behind, ok := g.AheadBehind(ctx, appPath)
+	if !ok || behind == 0 {
 		return nil, nil
 	}
 	if ahead > 0 {
@@ -253,32 +709,41 @@ func updateRepoIfNeeded(appPath string) (*updateInfo, error) {
 	}
 
 	hasChanges := false
-	status, err := gitOutput(appPath, "status", "--porcelain")
-	if err == nil && strings.TrimSpace(status) != "" {
+	status, err := g.Status(ctx, appPath)
+	if err == nil && status != "" {
+		if confirm != nil {
+			ok, err := confirm(status)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, errUpdateDeclined
+			}
+		}
 		hasChanges = true
-		if err := gitRun(appPath, "stash", "push", "-u", "-m", "kiosk: pre-update stash"); err != nil {
+		if err := g.Stash(ctx, appPath, "kiosk: pre-update stash"); err != nil {
 			return nil, fmt.Errorf("failed to stash local changes: %w", err)
 		}
 	}
 
-	if err := gitRun(appPath, "pull", "--ff-only"); err != nil {
+	if err := g.Pull(ctx, appPath); err != nil {
 		if hasChanges {
-			_ = gitRun(appPath, "stash", "pop")
+			_ = g.StashPop(ctx, appPath)
 		}
 		return nil, err
 	}
 
-	newCommit, err := gitOutput(appPath, "rev-parse", "HEAD")
+	newCommit, err := g.RevParse(ctx, appPath, "HEAD")
 	if err != nil {
 		if hasChanges {
-			_ = gitRun(appPath, "stash", "pop")
+			_ = g.StashPop(ctx, appPath)
 		}
 		return nil, err
 	}
 
 	unstashConflicts := false
 	if hasChanges {
-		if err := gitRun(appPath, "stash", "pop"); err != nil {
+		if err := g.StashPop(ctx, appPath); err != nil {
 			unstashConflicts = true
 		}
 	}
@@ -292,13 +757,87 @@ func updateRepoIfNeeded(appPath string) (*updateInfo, error) {
 	}, nil
 }
 
-func buildUpdatePrompt(info *updateInfo) string {
+// maxChangelogLines caps how many commits printChangelog lists
+const maxChangelogLines = 20
+
+// printChangelog lists the commits an update pulled in
+func printChangelog(appPath string, info *updateInfo) {
+	log, err := gitOutput(appPath, "log", "--oneline", "--no-decorate", info.oldCommit+".."+info.newCommit)
+	if err != nil || log == "" {
+		return
+	}
+
+	lines := strings.Split(log, "\n")
+	infof("Updated %s..%s:\n", shortCommit(info.oldCommit), shortCommit(info.newCommit))
+	for i, line := range lines {
+		if i == maxChangelogLines {
+			infof("  ... and %d more\n", len(lines)-maxChangelogLines)
+			break
+		}
+		infof("  %s\n", line)
+	}
+	infof("\n")
+}
+
+// shortCommit abbreviates a commit hash for display
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
+
+// confirmStash tells the user about local changes that are about to be
+// stashed for an update and asks whether to go ahead
+func confirmStash(status string) (bool, error) {
+	fmt.Fprintf(os.Stderr, "An update is available, and this app has local changes: %s.\n", git.SummarizeStatus(status))
+	fmt.Fprintln(os.Stderr, "They will be stashed during the update and re-applied afterwards.")
+	return confirmDefaultYes("Continue?")
+}
+
+// appRunPrompt returns the app's custom run prompt from the index, or the
+// default runPrompt if none is set
+func appRunPrompt(key string) string {
+	idx, err := appindex.Load()
+	if err != nil {
+		return runPrompt
+	}
+	if entry := idx.Get(key); entry != nil && strings.TrimSpace(entry.RunPrompt) != "" {
+		return entry.RunPrompt
+	}
+	return runPrompt
+}
+
+// openAppShell runs an interactive shell in the app's directory, for
+// kiosk run --shell
+func openAppShell(appPath string) error {
+	infof("Opening a shell in %s (exit to return)\n", appPath)
+	if err := runCommand(kioskexec.ShellCmd(), appPath); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			// The exit status of the last command run in the shell
+			return nil
+		}
+		return fmt.Errorf("failed to start shell: %w", err)
+	}
+	return nil
+}
+
+// workDirPrompt points Claude at an app installed at appPath while it works
+// in workDir
+func workDirPrompt(appPath, workDir, prompt string) string {
+	return fmt.Sprintf("This kiosk app is installed at %s; read its KIOSK.md and files from there, but work in the current directory (%s).\n%s", appPath, workDir, prompt)
+}
+
+func buildUpdatePrompt(info *updateInfo, basePrompt string) string {
 	var b strings.Builder
 	fmt.Fprintf(&b, "You are resuming an app that was previously set up and run at commit %s.\n", info.oldCommit)
 	fmt.Fprintf(&b, "The repository has been updated to commit %s on the current branch.\n", info.newCommit)
 	fmt.Fprintf(&b, "Review changes between %s and %s (git log --oneline %s..%s or git diff %s..%s).\n", info.oldCommit, info.newCommit, info.oldCommit, info.newCommit, info.oldCommit, info.newCommit)
 	if info.hadStash {
-		if info.unstashConflicts {
+		if info.stashDropped {
+			b.WriteString("Local changes were re-applied with merge conflicts and the user dropped the stash; resolve any conflict markers that remain.\n")
+		} else if info.unstashConflicts {
 			b.WriteString("Local changes were stashed and re-applied; resolve any merge conflicts from the unstash and drop the stash if it remains.\n")
 		} else {
 			b.WriteString("Local changes were stashed and re-applied; verify they still apply cleanly.\n")
@@ -306,25 +845,10 @@ func buildUpdatePrompt(info *updateInfo) string {
 	}
 	b.WriteString("Apply any configuration fixes or updates needed to get the app running again for the user.\n")
 	b.WriteString("Prompt the user once installation is complete: ask what they'd like to do next via multiple choice. Tailor options to the app—some are runnable apps (dev server, production build), others are workflow-oriented (scripts, generators, automation). For workflows, offer to help run them interactively.\n")
-	b.WriteString(runPrompt)
+	b.WriteString(basePrompt)
 	return b.String()
 }
 
-func cloneRepo(gitURL, dest string) error {
-	if gitURL == "" {
-		return fmt.Errorf("app has no git URL to clone")
-	}
-
-	cmd := exec.Command("git", "clone", gitURL, dest)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to clone repo: %w", err)
-	}
-	return nil
-}
-
 func runCommand(cmd *exec.Cmd, dir string) error {
 	cmd.Dir = dir
 	cmd.Stdin = os.Stdin
@@ -334,32 +858,14 @@ func runCommand(cmd *exec.Cmd, dir string) error {
 	return cmd.Run()
 }
 
-func gitOutput(dir string, args ...string) (string, error) {
-	cmd := exec.Command("git", args...)
-	cmd.Dir = dir
-	output, err := cmd.CombinedOutput()
+// isCloneOf reports whether dir is a git repo whose origin points at gitURL
+func isCloneOf(dir, gitURL string) bool {
+	origin, err := originURL(dir)
 	if err != nil {
-		out := strings.TrimSpace(string(output))
-		if out != "" {
-			return "", fmt.Errorf("git %s failed: %s", strings.Join(args, " "), out)
-		}
-		return "", fmt.Errorf("git %s failed: %w", strings.Join(args, " "), err)
+		return false
 	}
-	return strings.TrimSpace(string(output)), nil
-}
 
-func gitRun(dir string, args ...string) error {
-	cmd := exec.Command("git", args...)
-	cmd.Dir = dir
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		out := strings.TrimSpace(string(output))
-		if out != "" {
-			return fmt.Errorf("git %s failed: %s", strings.Join(args, " "), out)
-		}
-		return fmt.Errorf("git %s failed: %w", strings.Join(args, " "), err)
-	}
-	return nil
+	return giturl.Same(gitURL, origin)
 }
 
 type claudeSessionConfig struct {
@@ -376,12 +882,67 @@ func execClaude(dir, prompt string, safe bool) error {
 	}
 
 	cmd := kioskexec.ClaudeCmd("--permission-mode", permissionMode, prompt)
+	applyClaudeEnv(cmd)
 	return runCommand(cmd, dir)
 }
 
+// execClaudeTask runs claude non-interactively in dir on the app's prompt
+// followed by task, printing its reply. Claude gets no stdin, since the task
+// may have been read from it.
+func execClaudeTask(dir, prompt, task string, safe bool) error {
+	permissionMode := "bypassPermissions"
+	if safe {
+		permissionMode = "default"
+	}
+
+	cmd := kioskexec.ClaudeCmd("-p", "--permission-mode", permissionMode, prompt+"\n\nYour task for this run:\n"+task)
+	applyClaudeEnv(cmd)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// readStdinPrompt reads the task for --prompt - from stdin, which has to be
+// piped rather than a terminal
+func readStdinPrompt() (string, error) {
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("--prompt - reads the prompt from stdin; pipe it in, e.g. echo \"fix the failing test\" | kiosk run <app> --prompt -")
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read prompt from stdin: %w", err)
+	}
+	prompt := strings.TrimSpace(string(data))
+	if prompt == "" {
+		return "", fmt.Errorf("no prompt on stdin")
+	}
+	return prompt, nil
+}
+
+// applyClaudeEnv adds the variables from --env-file to cmd's environment
+func applyClaudeEnv(cmd *exec.Cmd) {
+	if len(claudeEnv) == 0 {
+		return
+	}
+	environ := cmd.Env
+	if environ == nil {
+		environ = os.Environ()
+	}
+	cmd.Env = dotenv.Merge(environ, claudeEnv)
+}
+
+// execClaudeSession runs claude for kiosk run. If claude exits with a
+// non-zero status the error is a kioskerrors.ExitError, so kiosk exits with
+// the same code.
 func execClaudeSession(dir, prompt string, safe bool, appKey string, sessionCfg *claudeSessionConfig) error {
+	// Said now rather than after a session that may run for hours
+	printConfigWarnings()
+	if runPromptFlag != "" {
+		return kioskerrors.NewExitError(execClaudeTask(dir, prompt, runPromptFlag, safe))
+	}
 	if sessionCfg == nil || sessionCfg.Store == nil {
-		return execClaude(dir, prompt, safe)
+		return kioskerrors.NewExitError(execClaude(dir, prompt, safe))
 	}
 
 	permissionMode := "bypassPermissions"
@@ -406,6 +967,7 @@ func execClaudeSession(dir, prompt string, safe bool, appKey string, sessionCfg
 
 	cmd := kioskexec.ClaudeCmd(args...)
 	cmd.Dir = dir
+	applyClaudeEnv(cmd)
 
 	runErr := claude.RunWithPTY(cmd, claude.SessionOptions{
 		IO:        sessionCfg.IO,
@@ -416,7 +978,7 @@ func execClaudeSession(dir, prompt string, safe bool, appKey string, sessionCfg
 			return errors.Join(runErr, fmt.Errorf("failed to clear session: %w", clearErr))
 		}
 	}
-	return runErr
+	return kioskerrors.NewExitError(runErr)
 }
 
 func shouldClearSession(err error) bool {
@@ -435,8 +997,40 @@ func shouldClearSession(err error) bool {
 
 func init() {
 	rootCmd.AddCommand(runCmd)
-	runCmd.Flags().StringVar(&sandboxFlag, "sandbox", "", "sandbox mode: comma-separated list of 'default', 'fs', 'net'")
+	runCmd.Flags().StringVar(&sandboxFlag, "sandbox", "", "sandbox mode: comma-separated list of 'default', 'fs', 'net', 'net:<domain>'; overrides the app's defaults")
+	runCmd.Flags().BoolVar(&noSandboxFlag, "no-sandbox", false, "don't apply the sandbox defaults from the app's KIOSK.md")
 	runCmd.Flags().BoolVar(&safeFlag, "safe", false, "run with default permission mode (prompts for permissions)")
+	runCmd.Flags().StringVar(&runCwdFlag, "cwd", "", "directory for Claude to work in instead of the app's install directory")
+	runCmd.Flags().BoolVar(&runDetachFlag, "detach", false, "run in a saved session that ctrl+k ends and --resume picks up again")
+	runCmd.Flags().BoolVar(&runResumeFlag, "resume", false, "reattach to the app's saved session")
+	runCmd.Flags().BoolVar(&noChangelogFlag, "no-changelog", false, "don't list the commits pulled in when the app updates")
+	runCmd.Flags().StringVar(&runEnvFileFlag, "env-file", "", "load environment variables for the app from a dotenv file")
+	runCmd.Flags().BoolVar(&clearSandboxFlag, "clear", false, "remove sandbox settings left in the app's .claude settings before running")
+	runCmd.Flags().IntVar(&runDepthFlag, "depth", defaultCloneDepth, "commits of history to clone (0 for all; also fetches the rest for an installed shallow app)")
+	runCmd.Flags().BoolVar(&runShellFlag, "shell", false, "open a shell in the app's directory instead of launching Claude")
+	runCmd.Flags().BoolVar(&printPromptFlag, "print-prompt", false, "print the prompt Claude would be given instead of launching it")
+	runCmd.Flags().BoolVar(&runRefreshFlag, "refresh", false, "look the app up on kiosk.app again instead of using the cached result")
+	runCmd.Flags().StringVar(&runPromptFlag, "prompt", "", "run non-interactively on this task and print Claude's reply ('-' reads it from stdin)")
+	runCmd.Flags().StringVar(&runOutputDirFlag, "output-dir", "", "install the app into this directory instead of the apps directory")
+	runCmd.Flags().BoolVar(&skipRequirementsFlag, "skip-requirements", false, "launch even if runtimes the app requires are missing or too old")
+	runCmd.Flags().StringVar(&claudePathFlag, "claude-path", "", "claude binary to run instead of the one in PATH")
+	runCmd.Flags().BoolVar(&runForceFlag, "force", false, "replace an installed app with the same name from another repository")
+	runCmd.Flags().BoolVar(&runSkipFlag, "skip", false, "keep an installed app with the same name from another repository and run it")
+	runCmd.MarkFlagsMutuallyExclusive("force", "skip")
+	runCmd.MarkFlagsMutuallyExclusive("cwd", "sandbox")
+	runCmd.MarkFlagsMutuallyExclusive("cwd", "output-dir")
+	runCmd.MarkFlagsMutuallyExclusive("clear", "sandbox")
+	runCmd.MarkFlagsMutuallyExclusive("no-sandbox", "sandbox")
+	for _, flag := range []string{"sandbox", "cwd", "detach", "resume", "print-prompt"} {
+		runCmd.MarkFlagsMutuallyExclusive("shell", flag)
+	}
+	// A task runs to completion without a terminal session
+	for _, flag := range []string{"shell", "detach", "resume", "print-prompt"} {
+		runCmd.MarkFlagsMutuallyExclusive("prompt", flag)
+	}
+	// Claude keeps sessions per directory, so a session can't follow --cwd
+	runCmd.MarkFlagsMutuallyExclusive("cwd", "detach")
+	runCmd.MarkFlagsMutuallyExclusive("cwd", "resume")
 }
 
 // parseSandboxValues parses and validates the sandbox flag value
@@ -454,8 +1048,12 @@ func parseSandboxValues(input string) ([]string, error) {
 		if v == "" {
 			continue
 		}
-		if !validValues[v] {
-			return nil, fmt.Errorf("invalid sandbox value: %q (valid: default, fs, net)", v)
+		domain, isDomain := strings.CutPrefix(v, "net:")
+		if isDomain && (domain == "" || strings.ContainsAny(domain, " /")) {
+			return nil, fmt.Errorf("invalid sandbox domain: %q (expected e.g. net:api.example.com)", v)
+		}
+		if !validValues[v] && !isDomain {
+			return nil, fmt.Errorf("invalid sandbox value: %q (valid: default, fs, net, net:<domain>)", v)
 		}
 		if !seen[v] {
 			seen[v] = true
@@ -492,26 +1090,70 @@ func transformSandboxValues(values []string) []string {
 	return result
 }
 
-// writeSandboxSettings creates or updates .claude/settings.json with sandbox config
+// applySandbox writes the sandbox settings for a run to the app's
+// .claude/settings.local.json: the --sandbox values if given, otherwise the
+// defaults the app's KIOSK.md recommends unless --no-sandbox is set. It says
+// on out which policy applies and where it came from.
+func applySandbox(appPath string, flagValues []string, out io.Writer) error {
+	values, source := flagValues, "--sandbox"
+	if len(values) == 0 {
+		if noSandboxFlag {
+			if hasSandboxSettings(appPath) && !quiet {
+				fmt.Fprintf(out, "Sandbox settings from an earlier run are still in .claude; add --clear to remove them\n")
+			}
+			return nil
+		}
+		defaults, err := appSandboxDefaults(appPath)
+		if err != nil {
+			return err
+		}
+		values, source = defaults, "the app's KIOSK.md defaults; --no-sandbox skips them"
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	if !quiet {
+		fmt.Fprintf(out, "Sandbox: %s (from %s)\n", strings.Join(values, ", "), source)
+	}
+	if err := writeSandboxSettings(appPath, values); err != nil {
+		return fmt.Errorf("failed to configure sandbox: %w", err)
+	}
+	return nil
+}
+
+// appSandboxDefaults returns the sandbox values declared in the app's
+// KIOSK.md frontmatter, expanded like --sandbox values
+func appSandboxDefaults(appPath string) ([]string, error) {
+	info, err := kioskmd.ReadInfo(appPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read KIOSK.md: %w", err)
+	}
+	values, err := parseSandboxValues(strings.Join(info.Sandbox, ","))
+	if err != nil {
+		return nil, fmt.Errorf("invalid sandbox in the app's KIOSK.md: %w", err)
+	}
+	return transformSandboxValues(values), nil
+}
+
+// writeSandboxSettings creates or updates .claude/settings.local.json with
+// sandbox config. It's excluded from git so the app doesn't look changed.
 func writeSandboxSettings(appPath string, sandboxValues []string) error {
 	if len(sandboxValues) == 0 {
 		return nil
 	}
 
-	claudeDir := filepath.Join(appPath, ".claude")
-	settingsPath := filepath.Join(claudeDir, "settings.json")
-
 	// Ensure .claude directory exists
-	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+	if err := os.MkdirAll(filepath.Join(appPath, ".claude"), 0755); err != nil {
 		return fmt.Errorf("failed to create .claude directory: %w", err)
 	}
 
-	// Load existing settings or create empty object
-	settings := make(map[string]interface{})
-	if data, err := os.ReadFile(settingsPath); err == nil {
-		if err := json.Unmarshal(data, &settings); err != nil {
-			return fmt.Errorf("failed to parse existing settings.json: %w", err)
-		}
+	settingsPath, settings, err := loadClaudeSettings(appPath)
+	if err != nil {
+		return err
 	}
 
 	// Build sandbox config
@@ -521,6 +1163,7 @@ func writeSandboxSettings(appPath string, sandboxValues []string) error {
 
 	hasFS := false
 	hasNet := false
+	domains := []string{}
 	for _, v := range sandboxValues {
 		if v == "fs" {
 			hasFS = true
@@ -528,6 +1171,10 @@ func writeSandboxSettings(appPath string, sandboxValues []string) error {
 		if v == "net" {
 			hasNet = true
 		}
+		if domain, ok := strings.CutPrefix(v, "net:"); ok {
+			hasNet = true
+			domains = append(domains, domain)
+		}
 	}
 
 	if hasFS {
@@ -539,19 +1186,93 @@ func writeSandboxSettings(appPath string, sandboxValues []string) error {
 	}
 
 	if hasNet {
-		sandboxConfig["allowedDomains"] = []string{}
+		sandboxConfig["allowedDomains"] = domains
 	}
 
 	settings["sandbox"] = sandboxConfig
 
-	// Write settings back
+	if err := saveClaudeSettings(settingsPath, settings); err != nil {
+		return err
+	}
+	return excludeFromGit(appPath, "**/.claude/"+claudeLocalSettingsFile)
+}
+
+// claudeLocalSettingsFile holds the sandbox settings kiosk writes. Claude
+// reads it over the project's settings.json, and it isn't meant to be
+// committed.
+const claudeLocalSettingsFile = "settings.local.json"
+
+// sandboxSettingsFiles are the files in .claude that may hold sandbox
+// settings from kiosk; earlier versions wrote them to settings.json
+var sandboxSettingsFiles = []string{claudeLocalSettingsFile, "settings.json"}
+
+// clearSandboxSettings removes the sandbox config from dir's .claude
+// settings files, keeping any other settings. It reports whether there was
+// one to remove.
+func clearSandboxSettings(dir string) (bool, error) {
+	cleared := false
+	for _, name := range sandboxSettingsFiles {
+		settingsPath := filepath.Join(dir, ".claude", name)
+		settings, err := readClaudeSettings(settingsPath)
+		if err != nil {
+			return cleared, err
+		}
+		if _, ok := settings["sandbox"]; !ok {
+			continue
+		}
+
+		delete(settings, "sandbox")
+		if err := saveClaudeSettings(settingsPath, settings); err != nil {
+			return cleared, err
+		}
+		cleared = true
+	}
+	return cleared, nil
+}
+
+// hasSandboxSettings reports whether dir's .claude settings files hold
+// sandbox config
+func hasSandboxSettings(dir string) bool {
+	for _, name := range sandboxSettingsFiles {
+		if settings, err := readClaudeSettings(filepath.Join(dir, ".claude", name)); err == nil && settings["sandbox"] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// loadClaudeSettings reads dir's .claude/settings.local.json, returning an
+// empty object if it doesn't exist
+func loadClaudeSettings(dir string) (string, map[string]interface{}, error) {
+	settingsPath := filepath.Join(dir, ".claude", claudeLocalSettingsFile)
+	settings, err := readClaudeSettings(settingsPath)
+	if err != nil {
+		return "", nil, err
+	}
+	return settingsPath, settings, nil
+}
+
+// readClaudeSettings reads a Claude settings file, returning an empty
+// object if it doesn't exist
+func readClaudeSettings(settingsPath string) (map[string]interface{}, error) {
+	settings := make(map[string]interface{})
+	if data, err := os.ReadFile(settingsPath); err == nil {
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return nil, fmt.Errorf("failed to parse existing %s: %w", filepath.Base(settingsPath), err)
+		}
+	}
+	return settings, nil
+}
+
+// saveClaudeSettings writes settings back to settingsPath
+func saveClaudeSettings(settingsPath string, settings map[string]interface{}) error {
 	data, err := json.MarshalIndent(settings, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal settings: %w", err)
 	}
 
 	if err := os.WriteFile(settingsPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write settings.json: %w", err)
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(settingsPath), err)
 	}
 
 	return nil
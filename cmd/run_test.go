@@ -1,10 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/git"
 )
 
 func TestParseSandboxValues(t *testing.T) {
@@ -64,6 +72,16 @@ func TestParseSandboxValues(t *testing.T) {
 			input:   "fs,invalid",
 			wantErr: true,
 		},
+		{
+			name:  "allowed domain",
+			input: "fs,net:api.example.com",
+			want:  []string{"fs", "net:api.example.com"},
+		},
+		{
+			name:    "empty domain",
+			input:   "net:",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -209,7 +227,7 @@ func TestWriteSandboxSettings(t *testing.T) {
 					t.Fatal(err)
 				}
 				data, _ := json.Marshal(tt.existingConfig)
-				if err := os.WriteFile(filepath.Join(claudeDir, "settings.json"), data, 0644); err != nil {
+				if err := os.WriteFile(filepath.Join(claudeDir, "settings.local.json"), data, 0644); err != nil {
 					t.Fatal(err)
 				}
 			}
@@ -221,7 +239,7 @@ func TestWriteSandboxSettings(t *testing.T) {
 			}
 
 			// Check the result
-			settingsPath := filepath.Join(tmpDir, ".claude", "settings.json")
+			settingsPath := filepath.Join(tmpDir, ".claude", "settings.local.json")
 
 			if tt.wantConfig == nil {
 				// File should not exist
@@ -279,6 +297,136 @@ func TestWriteSandboxSettings(t *testing.T) {
 	}
 }
 
+func TestApplySandbox(t *testing.T) {
+	defer func() { noSandboxFlag = false }()
+
+	readSandbox := func(dir string) map[string]any {
+		t.Helper()
+		_, settings, err := loadClaudeSettings(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sandbox, _ := settings["sandbox"].(map[string]any)
+		return sandbox
+	}
+	newApp := func() string {
+		dir := t.TempDir()
+		kioskMd := "---\nsandbox: [net:api.example.com]\n---\n# App\n"
+		if err := os.WriteFile(filepath.Join(dir, "KIOSK.md"), []byte(kioskMd), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+
+	// The app's defaults apply without --sandbox
+	dir := newApp()
+	if err := applySandbox(dir, nil, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if got := readSandbox(dir)["allowedDomains"]; !reflect.DeepEqual(got, []any{"api.example.com"}) {
+		t.Errorf("app defaults: allowedDomains = %v; want [api.example.com]", got)
+	}
+
+	// --sandbox overrides them
+	dir = newApp()
+	if err := applySandbox(dir, []string{"fs"}, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if sandbox := readSandbox(dir); sandbox["allowedDomains"] != nil || sandbox["allowedDirectories"] == nil {
+		t.Errorf("--sandbox fs: got %v; want only allowedDirectories", sandbox)
+	}
+
+	// --no-sandbox skips them
+	noSandboxFlag = true
+	dir = newApp()
+	if err := applySandbox(dir, nil, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if sandbox := readSandbox(dir); sandbox != nil {
+		t.Errorf("--no-sandbox: got %v; want no sandbox settings", sandbox)
+	}
+}
+
+func TestClearSandboxSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Nothing to clear without a settings file
+	cleared, err := clearSandboxSettings(tmpDir)
+	if err != nil || cleared {
+		t.Fatalf("clearSandboxSettings() = %v, %v; want false, nil", cleared, err)
+	}
+
+	claudeDir := filepath.Join(tmpDir, ".claude")
+	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Earlier versions wrote sandbox settings to settings.json
+	data, _ := json.Marshal(map[string]any{"otherSetting": "preserved", "sandbox": map[string]any{"enabled": true}})
+	if err := os.WriteFile(filepath.Join(claudeDir, "settings.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeSandboxSettings(tmpDir, []string{"fs", "net"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cleared, err = clearSandboxSettings(tmpDir)
+	if err != nil || !cleared {
+		t.Fatalf("clearSandboxSettings() = %v, %v; want true, nil", cleared, err)
+	}
+
+	for _, name := range []string{"settings.json", "settings.local.json"} {
+		data, err = os.ReadFile(filepath.Join(claudeDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got map[string]any
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := got["sandbox"]; ok {
+			t.Errorf("sandbox key was not removed from %s", name)
+		}
+		if name == "settings.json" && got["otherSetting"] != "preserved" {
+			t.Error("existing config was not preserved")
+		}
+	}
+	if hasSandboxSettings(tmpDir) {
+		t.Error("hasSandboxSettings() = true after clearing")
+	}
+}
+
+func TestSandboxSettingsExcludedFromGit(t *testing.T) {
+	if !git.Available() {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	if err := exec.Command("git", "init", "-q", dir).Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Writing twice adds the exclude pattern once
+	for range 2 {
+		if err := writeSandboxSettings(dir, []string{"fs"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	status, err := exec.Command("git", "-C", dir, "status", "--porcelain", "--untracked-files=all").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status) != 0 {
+		t.Errorf("git status after writing sandbox settings = %q; want a clean checkout", status)
+	}
+	exclude, err := os.ReadFile(filepath.Join(dir, ".git", "info", "exclude"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(exclude), claudeLocalSettingsFile); n != 1 {
+		t.Errorf("info/exclude mentions %s %d times; want 1", claudeLocalSettingsFile, n)
+	}
+}
+
 func sliceEqual(a, b []string) bool {
 	if len(a) != len(b) {
 		return false
@@ -290,3 +438,110 @@ func sliceEqual(a, b []string) bool {
 	}
 	return true
 }
+
+// scriptedGit answers git commands from a script of outputs, consumed in
+// order per command, and records the commands it was asked to run
+type scriptedGit struct {
+	outputs map[string][]string // command -> outputs; "!" prefix fails
+	calls   []string
+}
+
+func (s *scriptedGit) Run(ctx context.Context, dir string, stdout, stderr io.Writer, args ...string) error {
+	name := strings.Join(args, " ")
+	s.calls = append(s.calls, name)
+	queue := s.outputs[name]
+	if len(queue) == 0 {
+		return nil
+	}
+	out := queue[0]
+	s.outputs[name] = queue[1:]
+	if msg, failed := strings.CutPrefix(out, "!"); failed {
+		io.WriteString(stderr, msg)
+		return errors.New("exit status 1")
+	}
+	io.WriteString(stdout, out)
+	return nil
+}
+
+func TestUpdateRepo(t *testing.T) {
+	tests := []struct {
+		name      string
+		outputs   map[string][]string
+		confirm   func(string) (bool, error)
+		want      *updateInfo
+		wantErr   error
+		wantCalls []string
+	}{
+		{
+			name: "up to date",
+			outputs: map[string][]string{
+				"rev-parse --is-inside-work-tree":           {"true"},
+				"rev-parse HEAD":                            {"aaa"},
+				"rev-list --left-right --count HEAD...@{u}": {"0\t0"},
+			},
+			wantCalls: []string{"rev-parse --is-inside-work-tree", "rev-parse HEAD", "fetch --quiet", "rev-list --left-right --count HEAD...@{u}"},
+		},
+		{
+			name: "stashes around the pull",
+			outputs: map[string][]string{
+				"rev-parse --is-inside-work-tree":           {"true"},
+				"rev-parse HEAD":                            {"aaa", "bbb"},
+				"rev-list --left-right --count HEAD...@{u}": {"0\t2"},
+				"status --porcelain":                        {" M app.js"},
+				"stash pop":                                 {"!CONFLICT (content)"},
+			},
+			want: &updateInfo{updated: true, oldCommit: "aaa", newCommit: "bbb", hadStash: true, unstashConflicts: true},
+			wantCalls: []string{
+				"rev-parse --is-inside-work-tree", "rev-parse HEAD", "fetch --quiet",
+				"rev-list --left-right --count HEAD...@{u}", "status --porcelain",
+				"stash push -u -m kiosk: pre-update stash", "pull --ff-only", "rev-parse HEAD", "stash pop",
+			},
+		},
+		{
+			name: "declined stash",
+			outputs: map[string][]string{
+				"rev-parse --is-inside-work-tree":           {"true"},
+				"rev-parse HEAD":                            {"aaa"},
+				"rev-list --left-right --count HEAD...@{u}": {"0\t1"},
+				"status --porcelain":                        {"?? notes.txt"},
+			},
+			confirm: func(string) (bool, error) { return false, nil },
+			wantErr: errUpdateDeclined,
+		},
+		{
+			name: "failed pull restores the stash",
+			outputs: map[string][]string{
+				"rev-parse --is-inside-work-tree":           {"true"},
+				"rev-parse HEAD":                            {"aaa"},
+				"rev-list --left-right --count HEAD...@{u}": {"0\t1"},
+				"status --porcelain":                        {" M app.js"},
+				"pull --ff-only":                            {"!fatal: not possible to fast-forward"},
+			},
+			wantErr: errors.New("git pull --ff-only failed: fatal: not possible to fast-forward"),
+			wantCalls: []string{
+				"rev-parse --is-inside-work-tree", "rev-parse HEAD", "fetch --quiet",
+				"rev-list --left-right --count HEAD...@{u}", "status --porcelain",
+				"stash push -u -m kiosk: pre-update stash", "pull --ff-only", "stash pop",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &scriptedGit{outputs: tt.outputs}
+			got, err := updateRepo(context.Background(), &git.Git{Runner: runner}, "/app", tt.confirm)
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Fatalf("updateRepo() error = %v", err)
+			case tt.wantErr != nil && (err == nil || (!errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error())):
+				t.Fatalf("updateRepo() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("updateRepo() = %+v, want %+v", got, tt.want)
+			}
+			if tt.wantCalls != nil && !reflect.DeepEqual(runner.calls, tt.wantCalls) {
+				t.Errorf("git calls = %q\nwant %q", runner.calls, tt.wantCalls)
+			}
+		})
+	}
+}
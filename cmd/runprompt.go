@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
+	"github.com/spf13/cobra"
+)
+
+var runPromptCmd = &cobra.Command{
+	Use:   "run-prompt",
+	Short: "Manage per-app run prompts",
+	Long: `Manage the prompt Claude is started with when running an installed app.
+
+An app with no run prompt set uses the default prompt. When an app has been
+updated since it was last run, update instructions are added before it.`,
+}
+
+var runPromptSetCmd = &cobra.Command{
+	Use:   "set <org/repo> <prompt>",
+	Short: "Set an app's run prompt",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		idx, entry, err := loadIndexEntry(args[0])
+		if err != nil {
+			return err
+		}
+
+		entry.RunPrompt = args[1]
+		if err := appindex.Save(idx); err != nil {
+			return fmt.Errorf("failed to save app index: %w", err)
+		}
+
+		fmt.Printf("Run prompt set for %s\n", args[0])
+		return nil
+	},
+}
+
+var runPromptGetCmd = &cobra.Command{
+	Use:   "get <org/repo>",
+	Short: "Show an app's run prompt",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, entry, err := loadIndexEntry(args[0])
+		if err != nil {
+			return err
+		}
+
+		if entry.RunPrompt == "" {
+			fmt.Println(runPrompt)
+			return nil
+		}
+		fmt.Println(entry.RunPrompt)
+		return nil
+	},
+}
+
+var runPromptClearCmd = &cobra.Command{
+	Use:   "clear <org/repo>",
+	Short: "Reset an app to the default run prompt",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		idx, entry, err := loadIndexEntry(args[0])
+		if err != nil {
+			return err
+		}
+
+		entry.RunPrompt = ""
+		if err := appindex.Save(idx); err != nil {
+			return fmt.Errorf("failed to save app index: %w", err)
+		}
+
+		fmt.Printf("Run prompt cleared for %s\n", args[0])
+		return nil
+	},
+}
+
+// loadIndexEntry loads the app index and returns the entry for key
+func loadIndexEntry(key string) (*appindex.Index, *appindex.AppEntry, error) {
+	idx, err := appindex.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load app index: %w", err)
+	}
+
+	entry := idx.Get(key)
+	if entry == nil {
+		return nil, nil, fmt.Errorf("app %q is not installed", key)
+	}
+
+	return idx, entry, nil
+}
+
+func init() {
+	runPromptCmd.AddCommand(runPromptSetCmd)
+	runPromptCmd.AddCommand(runPromptGetCmd)
+	runPromptCmd.AddCommand(runPromptClearCmd)
+	rootCmd.AddCommand(runPromptCmd)
+}
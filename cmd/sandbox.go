@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
+	"github.com/spf13/cobra"
+)
+
+var sandboxCmd = &cobra.Command{
+	Use:   "sandbox",
+	Short: "Manage sandbox settings written by kiosk run --sandbox",
+}
+
+var sandboxClearCmd = &cobra.Command{
+	Use:   "clear <dir>",
+	Short: "Remove sandbox settings from a directory",
+	Long: `Remove the sandbox config that 'kiosk run --sandbox' wrote to
+<dir>/.claude/settings.local.json, or to <dir>/.claude/settings.json in
+earlier versions. Other settings in the files are kept.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", args[0], err)
+		}
+
+		cleared, err := clearSandboxSettings(dir)
+		if err != nil {
+			return err
+		}
+		if !cleared {
+			fmt.Printf("No sandbox settings in %s\n", dir)
+			return nil
+		}
+		fmt.Printf("Sandbox settings removed from %s\n", filepath.Join(dir, ".claude"))
+		return nil
+	},
+}
+
+// clearAppSandbox removes sandbox settings from an installed app, for
+// kiosk run --clear
+func clearAppSandbox(key string) error {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid app key: %s", key)
+	}
+
+	cleared, err := clearSandboxSettings(appindex.Path(key))
+	if err != nil {
+		return fmt.Errorf("failed to clear sandbox settings: %w", err)
+	}
+	if cleared {
+		infof("Cleared sandbox settings for %s\n", key)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(sandboxCmd)
+	sandboxCmd.AddCommand(sandboxClearCmd)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/components"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
+	"golang.org/x/term"
+)
+
+// spinnerFrames are the animation frames for withSpinner
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// withSpinner runs fn while showing msg next to a spinner, clearing the line
+// when fn returns. If fn takes long, how long is shown after msg so it doesn't
+// look hung. When stdout is not a terminal, msg is printed once instead.
+// Nothing is shown with --quiet.
+func withSpinner(msg string, fn func() error) error {
+	if quiet {
+		return fn()
+	}
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		fmt.Println(msg)
+		return fn()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	spinnerStyle := lipgloss.NewStyle().Foreground(styles.Primary)
+	textStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	start := time.Now()
+	render := func(i int) {
+		text := msg
+		if note := components.ElapsedNote(time.Since(start)); note != "" {
+			text += " (" + note + ")"
+		}
+		fmt.Print("\r" + spinnerStyle.Render(spinnerFrames[i]) + " " + textStyle.Render(text) + "\033[K")
+	}
+
+	ticker := time.NewTicker(80 * time.Millisecond)
+	defer ticker.Stop()
+
+	i := 0
+	render(i)
+	for {
+		select {
+		case err := <-done:
+			fmt.Print("\r\033[K") // Clear line
+			return err
+		case <-ticker.C:
+			i = (i + 1) % len(spinnerFrames)
+			render(i)
+		}
+	}
+}
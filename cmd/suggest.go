@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/api"
+	kioskerrors "github.com/reflective-technologies/kiosk-cli/internal/errors"
+	"github.com/reflective-technologies/kiosk-cli/internal/giturl"
+)
+
+// suggestTimeout bounds the app list fetch for suggestions, which only
+// improves an error message and isn't worth waiting on
+const suggestTimeout = 3 * time.Second
+
+// suggestPageSize is how many apps are compared against a mistyped name
+const suggestPageSize = 100
+
+// maxSuggestions is how many close matches are offered
+const maxSuggestions = 3
+
+// withAppSuggestions adds a hint to err, if it says appArg doesn't exist,
+// suggesting the closest app names on Kiosk. The error is still the API's
+// 404 underneath. Any other error, or a failure to list apps, leaves err as
+// is.
+func withAppSuggestions(client *api.Client, appArg string, err error) error {
+	apiErr, ok := kioskerrors.IsAPIError(err)
+	if !ok || !apiErr.IsNotFound() {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), suggestTimeout)
+	defer cancel()
+	page, listErr := client.ListAppsPaginatedContext(ctx, suggestPageSize, "")
+	if listErr != nil {
+		return err
+	}
+
+	suggestions := closestApps(appArg, page.Apps)
+	if len(suggestions) == 0 {
+		return err
+	}
+	return kioskerrors.WithHint(err, fmt.Sprintf("There's no app called %s on Kiosk; did you mean %s?", appArg, strings.Join(suggestions, " or ")))
+}
+
+// closestApps returns the names of up to maxSuggestions apps within a few
+// typos of query, closest first. Apps are named org/repo where the git URL
+// has one, and by ID otherwise. A query without an org is compared to app IDs
+// and repo names only.
+func closestApps(query string, apps []api.App) []string {
+	query = strings.ToLower(query)
+	_, queryRepo, hasOrg := strings.Cut(query, "/")
+	if !hasOrg {
+		queryRepo = query
+	}
+	limit := max(2, len(query)/4)
+
+	type match struct {
+		name     string
+		distance int
+	}
+	var matches []match
+	seen := make(map[string]bool)
+	for _, app := range apps {
+		name := giturl.ExtractOrgRepo(app.GitUrl)
+		if name == "" {
+			name = app.ID
+		}
+		lower := strings.ToLower(name)
+		if name == "" || seen[lower] {
+			continue
+		}
+
+		_, repo, _ := strings.Cut(lower, "/")
+		distance := min(editDistance(queryRepo, strings.ToLower(app.ID)), editDistance(queryRepo, repo))
+		if hasOrg {
+			distance = min(distance, editDistance(query, lower))
+		}
+		if distance <= limit {
+			seen[lower] = true
+			matches = append(matches, match{name, distance})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].distance < matches[j].distance })
+	var names []string
+	for _, m := range matches[:min(len(matches), maxSuggestions)] {
+		names = append(names, m.name)
+	}
+	return names
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
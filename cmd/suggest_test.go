@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/api"
+)
+
+func TestClosestApps(t *testing.T) {
+	apps := []api.App{
+		{ID: "claude-starter", GitUrl: "https://github.com/anthropic/claude-starter"},
+		{ID: "weather", GitUrl: "https://github.com/acme/weather"},
+		{ID: "feather", GitUrl: "https://github.com/birds/feather"},
+		{ID: "notes", GitUrl: "https://git.example.com/team/notes.git"},
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"claude-startr", []string{"anthropic/claude-starter"}},
+		{"anthropc/claude-starter", []string{"anthropic/claude-starter"}},
+		{"wether", []string{"acme/weather", "birds/feather"}},
+		{"note", []string{"notes"}},
+		{"something-else", nil},
+	}
+	for _, tt := range tests {
+		if got := closestApps(tt.query, apps); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("closestApps(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -2,12 +2,17 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
 	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	"github.com/reflective-technologies/kiosk-cli/internal/prefetch"
 	"github.com/reflective-technologies/kiosk-cli/internal/sessions"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/components"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui/views"
 	"github.com/spf13/cobra"
 )
@@ -30,19 +35,33 @@ func init() {
 	rootCmd.AddCommand(tuiCmd)
 }
 
+// loadKeyBindings applies the keys remapped in the config. It must run
+// before any view or prompt builds its key map.
+func loadKeyBindings() {
+	if cfg, err := config.Load(); err == nil {
+		for _, warning := range tui.SetKeyBindings(cfg.KeyBindings) {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+	}
+}
+
 func runTUI(cmd *cobra.Command, args []string) error {
+	loadKeyBindings()
+	spinnerStyle := tuiSpinnerStyle()
+
 	// Create the main TUI model
-	m := tui.New()
+	m := tui.New(spinnerStyle)
+	m.SetIdleTimeout(tuiIdleTimeout())
 
 	// Create view models (as pointers so SetSize works correctly)
 	homeView := views.NewHomeModel()
 	appListView := views.NewAppListModel()
-	appDetailView := views.NewAppDetailModel()
-	browseView := views.NewBrowseModel()
-	publishView := views.NewPublishModel()
+	appDetailView := views.NewAppDetailModel(spinnerStyle, gitClient())
+	browseView := views.NewBrowseModel(spinnerStyle)
+	publishView := views.NewPublishModel(spinnerStyle)
 	helpView := views.NewHelpModel()
-	loginView := views.NewLoginModel()
-	auditView := views.NewAuditModel()
+	loginView := views.NewLoginModel(spinnerStyle)
+	auditView := views.NewAuditModel(spinnerStyle)
 
 	// Set views on the main model (pass as pointers)
 	m.SetHomeView(&homeView)
@@ -54,6 +73,13 @@ func runTUI(cmd *cobra.Command, args []string) error {
 	m.SetLoginView(&loginView)
 	m.SetAuditView(&auditView)
 
+	// Welcome new users once, before anything is written to ~/.kiosk
+	if showOnboarding() {
+		onboardingView := views.NewOnboardingModel()
+		m.SetOnboardingView(&onboardingView)
+		m.StartAt(tui.ViewOnboarding)
+	}
+
 	sessionStore, err := sessions.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load session store: %w", err)
@@ -67,15 +93,14 @@ func runTUI(cmd *cobra.Command, args []string) error {
 	})
 	m.SetSessionDelete(sessionStore.Delete)
 
-	// Create the program with alternate screen buffer
-	p := tea.NewProgram(
+	// Run the TUI with alternate screen buffer, stopping any background
+	// fetch still running when it exits
+	finalModel, err := runProgram(
 		&m,
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
-
-	// Run the TUI
-	finalModel, err := p.Run()
+	prefetch.GetCache().Cancel()
 	if err != nil {
 		return fmt.Errorf("error running TUI: %w", err)
 	}
@@ -89,6 +114,19 @@ func runTUI(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// showOnboarding reports whether this is the first launch. It writes the
+// default config, without env var or flag overrides, so the kiosk directory
+// is no longer fresh and the onboarding is only shown once.
+func showOnboarding() bool {
+	if !config.IsFreshInstall() {
+		return false
+	}
+	if err := config.Save(config.Default()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save config: %v\n", err)
+	}
+	return true
+}
+
 // executeApp runs an app after TUI exits using the same logic as `kiosk run`
 func executeApp(appKey string) error {
 	// Ensure working directory is initialized
@@ -112,11 +150,40 @@ func executeApp(appKey string) error {
 
 	// Check if app is installed
 	if idx.Has(key) {
-		return runInstalledApp(key, nil, false, nil)
+		return runInstalledApp(key, "", nil, false, nil)
 	}
 
 	// App not installed - fetch from API and install
-	return installAndRunApp(cfg, idx, appKey, key, nil, false, nil)
+	return installAndRunApp(cfg, idx, appKey, key, "", nil, false, nil)
+}
+
+// tuiSpinnerStyle returns the spinner style set by ui.spinner, warning and
+// falling back to dot if it's unknown
+func tuiSpinnerStyle() spinner.Spinner {
+	cfg, err := config.Load()
+	if err != nil {
+		return components.SpinnerDot
+	}
+	style, ok := components.SpinnerStyle(cfg.UI.Spinner)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Warning: unknown ui.spinner %q, using dot\n", cfg.UI.Spinner)
+	}
+	return style
+}
+
+// tuiIdleTimeout returns the ui.idleTimeout duration, or 0 if it's unset or
+// invalid
+func tuiIdleTimeout() time.Duration {
+	cfg, err := config.Load()
+	if err != nil || cfg.UI.IdleTimeout == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(cfg.UI.IdleTimeout)
+	if err != nil || d <= 0 {
+		fmt.Fprintf(os.Stderr, "Warning: invalid ui.idleTimeout %q, ignoring it\n", cfg.UI.IdleTimeout)
+		return 0
+	}
+	return d
 }
 
 // postInstallModel wraps the TUI model to start in post-install mode
@@ -125,18 +192,29 @@ type postInstallModel struct {
 	appName string
 	appKey  string
 	appPath string
+
+	// From tuiSpinnerStyle before the program starts, since its warning
+	// can't be printed once the alt screen is up
+	spinnerStyle spinner.Spinner
+
+	// Output of an install running in the view, from runInstallTUI
+	events <-chan tea.Msg
 }
 
 func (m *postInstallModel) Init() tea.Cmd {
-	postInstallView := views.NewPostInstallModel(m.appName, m.appKey, m.appPath)
+	postInstallView := views.NewPostInstallModel(m.appName, m.appKey, m.appPath, m.spinnerStyle)
 	m.model.SetPostInstallView(&postInstallView)
 
-	return tea.Batch(
-		m.model.Init(),
-		func() tea.Msg {
-			return tui.NavigateMsg{View: tui.ViewPostInstall}
-		},
-	)
+	navigate := func() tea.Msg {
+		return tui.NavigateMsg{View: tui.ViewPostInstall}
+	}
+	if m.events == nil {
+		return tea.Batch(m.model.Init(), navigate)
+	}
+	// The install's output goes to the current view, so navigate first
+	return tea.Batch(m.model.Init(), tea.Sequence(navigate, func() tea.Msg {
+		return tui.InstallStartedMsg{Events: m.events}
+	}))
 }
 
 func (m *postInstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
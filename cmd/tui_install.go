@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/reflective-technologies/kiosk-cli/internal/api"
+	kioskexec "github.com/reflective-technologies/kiosk-cli/internal/exec"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui"
+)
+
+var installTUIFlag bool
+
+// runInstallTUI installs an app in the post-install view. The clone and the
+// install prompt, run with Claude in print mode, stream their output into
+// the view; register checks the clone and adds it to the index in between.
+// Quitting the view stops whichever step is still running.
+func runInstallTUI(app *api.App, key, appPath, prompt string, depth int, register func(out, warn io.Writer) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan tea.Msg)
+	result := make(chan error, 1)
+	go func() {
+		defer close(events)
+		result <- streamInstall(ctx, events, app, key, appPath, prompt, depth, register)
+	}()
+
+	spinnerStyle := tuiSpinnerStyle()
+	m := tui.New(spinnerStyle)
+	_, runErr := runProgram(&postInstallModel{
+		model:        &m,
+		appName:      app.Name,
+		appKey:       key,
+		appPath:      appPath,
+		spinnerStyle: spinnerStyle,
+		events:       events,
+	}, tea.WithAltScreen())
+
+	// Stop the install if the view was quit before it finished
+	var err error
+	select {
+	case err = <-result:
+	default:
+		cancel()
+		if err = <-result; err != nil {
+			err = fmt.Errorf("install of %s stopped before it finished", key)
+		}
+	}
+	if runErr != nil {
+		return fmt.Errorf("error running TUI: %w", runErr)
+	}
+	return err
+}
+
+// streamInstall clones the app, registers it, and runs its install prompt,
+// sending the output of each step and their outcomes on events
+func streamInstall(ctx context.Context, events chan<- tea.Msg, app *api.App, key, appPath, prompt string, depth int, register func(out, warn io.Writer) error) error {
+	send := func(msg tea.Msg) {
+		select {
+		case events <- msg:
+		case <-ctx.Done():
+		}
+	}
+	log := &installLogWriter{send: send}
+
+	err := gitClient().Clone(ctx, app.GitUrl, appPath, depth, log)
+	if err == nil {
+		err = register(log, log)
+	}
+	log.Flush()
+	send(tui.CloneCompleteMsg{Path: appPath, Err: err})
+	if err != nil {
+		return err
+	}
+
+	// Print mode, since the install prompt can't be answered from the view
+	cmd := kioskexec.ClaudeCmd("-p", "--permission-mode", "bypassPermissions", prompt)
+	applyClaudeEnv(cmd)
+	cmd.Dir = appPath
+	cmd.Stdout = log
+	cmd.Stderr = log
+	err = runUntilCancelled(ctx, cmd)
+	log.Flush()
+	if err != nil {
+		err = fmt.Errorf("claude failed to install %s: %w", key, err)
+	}
+	send(tui.AppInstalledMsg{Key: key, Err: err})
+	return err
+}
+
+// runUntilCancelled runs cmd, killing it if ctx is cancelled first
+func runUntilCancelled(ctx context.Context, cmd *exec.Cmd) error {
+	// Output pipes held open by anything claude started don't block Wait
+	cmd.WaitDelay = time.Second
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		_ = cmd.Process.Kill()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// installLogWriter sends what's written to it as tui.InstallLogMsg, a line
+// at a time. git's progress updates end in \r, so that splits lines too.
+type installLogWriter struct {
+	send func(tea.Msg)
+	buf  []byte
+}
+
+func (w *installLogWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexAny(w.buf, "\r\n")
+		if i < 0 {
+			break
+		}
+		w.sendLine(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush sends whatever is left after the last line break
+func (w *installLogWriter) Flush() {
+	w.sendLine(w.buf)
+	w.buf = nil
+}
+
+func (w *installLogWriter) sendLine(line []byte) {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return
+	}
+	w.send(tui.InstallLogMsg{Line: string(line)})
+}
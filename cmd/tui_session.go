@@ -61,10 +61,10 @@ func runAppWithSession(appArg string, store *sessions.Store, ioCfg claude.Sessio
 	}
 
 	if idx.Has(key) {
-		return runInstalledApp(key, nil, false, sessionCfg)
+		return runInstalledApp(key, "", nil, false, sessionCfg)
 	}
 
-	return installAndRunApp(cfg, idx, appArg, key, nil, false, sessionCfg)
+	return installAndRunApp(cfg, idx, appArg, key, "", nil, false, sessionCfg)
 }
 
 func runAppSessionCmd(appArg string, store *sessions.Store) tea.Cmd {
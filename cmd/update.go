@@ -3,16 +3,26 @@ package cmd
 import (
 	"archive/tar"
 	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/charmbracelet/glamour"
+	"github.com/reflective-technologies/kiosk-cli/internal/clistyle"
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 const (
@@ -20,42 +30,109 @@ const (
 	repoName  = "kiosk-cli"
 )
 
+// updateTempPattern names the temp dirs updates download into
+const updateTempPattern = "kiosk-update-*"
+
+// updateStagePattern names the new binary while it is staged next to the
+// executable
+const updateStagePattern = ".kiosk-update-*"
+
+// staleUpdateAge is how old an update temp dir must be before
+// sweepStaleUpdateDirs removes it, so a concurrent update isn't disturbed
+const staleUpdateAge = time.Hour
+
+// githubReleasesURL is where releases are downloaded from unless a mirror
+// is configured
+const githubReleasesURL = "https://github.com/" + repoOwner + "/" + repoName + "/releases"
+
+// updateBaseURL returns the release mirror set with $KIOSK_UPDATE_BASE_URL
+// or updates.baseUrl, or "" to use GitHub. A mirror serves the latest
+// release as <base>/latest, in the JSON shape of GitHub's releases API, and
+// assets as <base>/download/<tag>/<asset>, like github.com. Releases
+// aren't signed, so a mirror that isn't https is refused.
+func updateBaseURL() (string, error) {
+	base := os.Getenv(config.EnvUpdateBaseURL)
+	source := "$" + config.EnvUpdateBaseURL
+	if base == "" {
+		if cfg, err := config.Load(); err == nil {
+			base, source = cfg.Updates.BaseURL, "updates.baseUrl"
+		}
+	}
+	if base != "" && !strings.HasPrefix(base, "https://") {
+		return "", fmt.Errorf("release mirror %s (from %s) must use https", base, source)
+	}
+	return strings.TrimRight(base, "/"), nil
+}
+
+// updateHTTPClient fetches releases, refusing redirects away from https
+var updateHTTPClient = &http.Client{
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if req.URL.Scheme != "https" {
+			return fmt.Errorf("refusing to follow redirect to %s", req.URL.Redacted())
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	},
+}
+
 type githubRelease struct {
 	TagName string `json:"tag_name"`
+	Body    string `json:"body"` // release notes, in markdown
 }
 
+var updateNoNotes bool
+
 var updateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update kiosk to the latest version",
-	Long:  `Downloads and installs the latest version of kiosk from GitHub releases.`,
-	RunE:  runUpdate,
+	Long: `Downloads and installs the latest version of kiosk from GitHub releases.
+
+To update from an internal mirror instead, set $KIOSK_UPDATE_BASE_URL or
+updates.baseUrl to its base URL. The mirror serves the latest release's
+metadata at <base>/latest, as GitHub's releases API does, and assets at
+<base>/download/<tag>/<asset>.`,
+	RunE: runUpdate,
 }
 
 func init() {
+	updateCmd.Flags().BoolVar(&updateNoNotes, "no-notes", false, "don't show the new version's release notes")
 	rootCmd.AddCommand(updateCmd)
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
-	fmt.Printf("Current version: %s\n", Version)
+	info := currentBuildInfo()
+	fmt.Printf("Current version: %s\n", info.Version)
 
 	// Fetch latest version
-	latest, err := fetchLatestVersion()
+	release, err := fetchLatestRelease(context.Background())
 	if err != nil {
 		return fmt.Errorf("failed to fetch latest version: %w", err)
 	}
+	latest := release.TagName
 
 	fmt.Printf("Latest version: %s\n", latest)
 
-	// Compare versions
-	if Version == latest || "v"+Version == latest {
+	// Compare versions. Dev builds always install the latest release.
+	switch cmp, ok := compareVersions(info.Version, latest); {
+	case info.IsDev():
+		fmt.Printf("Installing %s\n", latest)
+	case !ok:
+		// The release tag isn't a semantic version; fall back to an exact match
+		if strings.TrimPrefix(info.Version, "v") == strings.TrimPrefix(latest, "v") {
+			fmt.Println("Already up to date!")
+			return nil
+		}
+		fmt.Printf("Updating %s -> %s\n", info.Version, latest)
+	case cmp == 0:
 		fmt.Println("Already up to date!")
 		return nil
-	}
-
-	if Version != "dev" {
-		fmt.Printf("Updating %s -> %s\n", Version, latest)
-	} else {
-		fmt.Printf("Installing %s\n", latest)
+	case cmp > 0:
+		fmt.Printf("Warning: current version %s is newer than the latest release %s; not updating.\n", info.Version, latest)
+		return nil
+	default:
+		fmt.Printf("Updating %s -> %s\n", info.Version, latest)
 	}
 
 	// Get current executable path
@@ -74,28 +151,79 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("Successfully updated to %s!\n", latest)
+	if !updateNoNotes {
+		printReleaseNotes(latest, release.Body)
+	}
 	return nil
 }
 
-func fetchLatestVersion() (string, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", repoOwner, repoName)
+// printReleaseNotes shows what changed in version, rendered as markdown on
+// a terminal
+func printReleaseNotes(version, notes string) {
+	notes = strings.TrimSpace(notes)
+	if notes == "" {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(clistyle.Title.Render("What's new in " + version))
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		renderer, err := glamour.NewTermRenderer(
+			glamour.WithAutoStyle(),
+			glamour.WithWordWrap(80),
+		)
+		if err == nil {
+			if rendered, err := renderer.Render(notes); err == nil {
+				fmt.Print(rendered)
+				return
+			}
+		}
+	}
+	fmt.Println()
+	fmt.Println(notes)
+}
 
-	resp, err := http.Get(url)
+func fetchLatestVersion(ctx context.Context) (string, error) {
+	release, err := fetchLatestRelease(ctx)
 	if err != nil {
 		return "", err
 	}
+	return release.TagName, nil
+}
+
+// fetchLatestRelease fetches the newest release from GitHub, or from the
+// configured mirror
+func fetchLatestRelease(ctx context.Context) (*githubRelease, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", repoOwner, repoName)
+	source := "GitHub API"
+	base, err := updateBaseURL()
+	if err != nil {
+		return nil, err
+	}
+	if base != "" {
+		url, source = base+"/latest", "release mirror "+base
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := updateHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("%s returned status %d", source, resp.StatusCode)
 	}
 
 	var release githubRelease
 	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return release.TagName, nil
+	return &release, nil
 }
 
 func downloadAndInstall(version, execPath string) error {
@@ -107,28 +235,36 @@ func downloadAndInstall(version, execPath string) error {
 	versionNum := strings.TrimPrefix(version, "v")
 
 	assetName := fmt.Sprintf("kiosk_%s_%s_%s.tar.gz", versionNum, goos, goarch)
-	downloadURL := fmt.Sprintf("https://github.com/%s/%s/releases/download/%s/%s",
-		repoOwner, repoName, version, assetName)
+	base, err := updateBaseURL()
+	if err != nil {
+		return err
+	}
+	if base == "" {
+		base = githubReleasesURL
+	}
+	downloadURL := fmt.Sprintf("%s/download/%s/%s", base, version, assetName)
 
 	fmt.Printf("Downloading %s...\n", assetName)
 
 	// Download to temp file
-	resp, err := http.Get(downloadURL)
+	resp, err := updateHTTPClient.Get(downloadURL)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("download failed with status %d", resp.StatusCode)
+		return fmt.Errorf("download of %s failed with status %d", downloadURL, resp.StatusCode)
 	}
 
 	// Create temp directory
-	tmpDir, err := os.MkdirTemp("", "kiosk-update-*")
+	tmpDir, err := os.MkdirTemp("", updateTempPattern)
 	if err != nil {
 		return err
 	}
 	defer os.RemoveAll(tmpDir)
+	track, stop := removeOnInterrupt(tmpDir)
+	defer stop()
 
 	// Extract binary from tarball
 	newBinaryPath, err := extractBinary(resp.Body, tmpDir)
@@ -137,13 +273,71 @@ func downloadAndInstall(version, execPath string) error {
 	}
 
 	// Replace current binary
-	if err := replaceBinary(newBinaryPath, execPath); err != nil {
+	if err := replaceBinary(newBinaryPath, execPath, track); err != nil {
 		return fmt.Errorf("failed to replace binary: %w", err)
 	}
 
 	return nil
 }
 
+// removeOnInterrupt removes path, and any path later passed to track, and
+// exits if the process is interrupted before stop is called, since deferred
+// cleanup doesn't run then
+func removeOnInterrupt(path string) (track func(string), stop func()) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+
+	var mu sync.Mutex
+	paths := []string{path}
+
+	go func() {
+		select {
+		case <-sigCh:
+			mu.Lock()
+			for _, p := range paths {
+				os.RemoveAll(p)
+			}
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	track = func(p string) {
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, p)
+	}
+	stop = func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+	return track, stop
+}
+
+// sweepStaleUpdateDirs removes update temp dirs, and new binaries staged
+// next to the executable, left behind by updates that were killed before
+// they could clean up
+func sweepStaleUpdateDirs() {
+	paths, err := filepath.Glob(filepath.Join(os.TempDir(), updateTempPattern))
+	if err != nil {
+		return
+	}
+	if execPath, err := os.Executable(); err == nil {
+		if execPath, err = filepath.EvalSymlinks(execPath); err == nil {
+			staged, _ := filepath.Glob(filepath.Join(filepath.Dir(execPath), updateStagePattern))
+			paths = append(paths, staged...)
+		}
+	}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || time.Since(info.ModTime()) < staleUpdateAge {
+			continue
+		}
+		os.RemoveAll(path)
+	}
+}
+
 func extractBinary(r io.Reader, destDir string) (string, error) {
 	gzr, err := gzip.NewReader(r)
 	if err != nil {
@@ -183,45 +377,110 @@ func extractBinary(r io.Reader, destDir string) (string, error) {
 	return "", fmt.Errorf("kiosk binary not found in archive")
 }
 
-func replaceBinary(newPath, oldPath string) error {
+// backupSuffix is appended to the binary's path to keep the previous version
+// while an update is being installed
+const backupSuffix = ".old"
+
+// replaceBinary installs the binary at newPath over oldPath. The new binary
+// is staged and synced next to the target, then renamed over it atomically,
+// so an interruption never leaves the target missing. The previous binary is
+// kept as a hard-linked backup until the swap succeeds.
+func replaceBinary(newPath, oldPath string, track func(string)) error {
 	// Get permissions from old binary
 	info, err := os.Stat(oldPath)
 	if err != nil {
 		return err
 	}
 
-	// On Unix, we can't write to a running binary, but we can rename it
-	// Rename old binary to .old
-	oldBackup := oldPath + ".old"
-	if err := os.Rename(oldPath, oldBackup); err != nil {
-		return fmt.Errorf("failed to backup old binary: %w", err)
+	staged, err := stageBinary(newPath, filepath.Dir(oldPath), info.Mode(), track)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(staged) // no-op once renamed into place
+
+	// Keep a recovery path to the old binary. Filesystems without hard links
+	// just go without one; the rename below is still atomic.
+	oldBackup := oldPath + backupSuffix
+	os.Remove(oldBackup)
+	hasBackup := os.Link(oldPath, oldBackup) == nil
+
+	if err := os.Rename(staged, oldPath); err != nil {
+		if hasBackup {
+			os.Remove(oldBackup)
+		}
+		return fmt.Errorf("failed to install new binary: %w", err)
+	}
+
+	if hasBackup {
+		os.Remove(oldBackup)
 	}
+	return nil
+}
 
-	// Copy new binary to target location
-	newFile, err := os.Open(newPath)
+// stageBinary copies src to a temporary file in dir with the given mode and
+// syncs it to disk, returning its path. The file is passed to track as soon
+// as it's created, so an interrupt removes it.
+func stageBinary(src, dir string, mode os.FileMode, track func(string)) (string, error) {
+	in, err := os.Open(src)
 	if err != nil {
-		// Restore old binary
-		os.Rename(oldBackup, oldPath)
-		return err
+		return "", err
 	}
-	defer newFile.Close()
+	defer in.Close()
 
-	outFile, err := os.OpenFile(oldPath, os.O_CREATE|os.O_WRONLY, info.Mode())
+	out, err := os.CreateTemp(dir, updateStagePattern)
 	if err != nil {
-		// Restore old binary
-		os.Rename(oldBackup, oldPath)
-		return err
+		return "", fmt.Errorf("failed to stage new binary: %w", err)
 	}
-	defer outFile.Close()
+	track(out.Name())
 
-	if _, err := io.Copy(outFile, newFile); err != nil {
-		// Restore old binary
-		os.Rename(oldBackup, oldPath)
-		return err
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(out.Name())
+		return "", err
+	}
+	if err := out.Chmod(mode); err != nil {
+		out.Close()
+		os.Remove(out.Name())
+		return "", err
 	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		os.Remove(out.Name())
+		return "", err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(out.Name())
+		return "", err
+	}
+	return out.Name(), nil
+}
 
-	// Remove backup
-	os.Remove(oldBackup)
+// recoverInterruptedUpdate repairs the install after an update that was
+// killed partway through. Older versions renamed kiosk to kiosk.old before
+// copying the new binary, so an interruption could leave only kiosk.old;
+// running that restores it to kiosk. A backup left next to a working binary
+// is stale and removed.
+func recoverInterruptedUpdate() {
+	execPath, err := os.Executable()
+	if err != nil {
+		return
+	}
+	execPath, err = filepath.EvalSymlinks(execPath)
+	if err != nil {
+		return
+	}
 
-	return nil
+	target := strings.TrimSuffix(execPath, backupSuffix)
+	backup := target + backupSuffix
+
+	if _, err := os.Stat(target); os.IsNotExist(err) {
+		if err := os.Rename(backup, target); err == nil {
+			fmt.Fprintf(os.Stderr, "Restored %s after an interrupted update\n", target)
+		}
+		return
+	}
+
+	if target == execPath {
+		os.Remove(backup)
+	}
 }
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
+	"github.com/reflective-technologies/kiosk-cli/internal/events"
+	"github.com/reflective-technologies/kiosk-cli/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// updateAppsConcurrency caps how many apps are fetched and pulled at once
+const updateAppsConcurrency = 4
+
+var (
+	updateAppsAll    bool
+	updateAppsDryRun bool
+)
+
+// appUpdateResult is the outcome of updating one app
+type appUpdateResult struct {
+	key     string
+	status  string // shown after the app key
+	updated bool   // pulled, or would be with --dry-run
+	skipped bool   // left alone for a reason the user should act on
+}
+
+var updateAppsCmd = &cobra.Command{
+	Use:   "update-apps [org/repo...]",
+	Short: "Pull updates for installed apps",
+	Long: `Fast-forward installed apps to their upstream branch without running them.
+This updates apps, not kiosk itself; use 'kiosk update' for that.
+
+Apps that are pinned to a version, have local changes, or have diverged from
+upstream are skipped with a warning. Run those with 'kiosk run' to update
+them interactively.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if updateAppsAll == (len(args) > 0) {
+			return fmt.Errorf("specify apps to update or --all")
+		}
+
+		idx, err := appindex.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load app index: %w", err)
+		}
+
+		keys := idx.List()
+		if !updateAppsAll {
+			keys = nil
+			for _, key := range args {
+				if !idx.Has(key) {
+					return fmt.Errorf("app %q is not installed", key)
+				}
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			infof("No apps installed.\n")
+			return nil
+		}
+
+		results := make([]appUpdateResult, len(keys))
+		sem := make(chan struct{}, updateAppsConcurrency)
+		var wg sync.WaitGroup
+		for i, key := range keys {
+			wg.Add(1)
+			go func(i int, key string) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				results[i] = updateApp(idx, key, updateAppsDryRun)
+			}(i, key)
+		}
+		wg.Wait()
+
+		var updated, skipped int
+		for _, r := range results {
+			if r.skipped {
+				skipped++
+				fmt.Fprintf(os.Stderr, "Warning: skipped %s: %s\n", r.key, r.status)
+				continue
+			}
+			if r.updated {
+				updated++
+				if !updateAppsDryRun {
+					_ = events.Record(events.Update, r.key)
+				}
+			}
+			fmt.Printf("%s: %s\n", r.key, r.status)
+		}
+
+		verb := "Updated"
+		if updateAppsDryRun {
+			verb = "Would update"
+		}
+		infof("\n%s %d of %d apps", verb, updated, len(results))
+		if skipped > 0 {
+			infof(" (%d skipped)", skipped)
+		}
+		infof("\n")
+		return nil
+	},
+}
+
+// updateApp fetches one app and fast-forwards it if it's behind upstream,
+// leaving pinned, dirty and diverged apps alone
+func updateApp(idx *appindex.Index, key string, dryRun bool) appUpdateResult {
+	result := appUpdateResult{key: key}
+	skip := func(format string, a ...any) appUpdateResult {
+		result.skipped = true
+		result.status = fmt.Sprintf(format, a...)
+		return result
+	}
+
+	entry := idx.Get(key)
+	if entry != nil && entry.Version != "" {
+		return skip("pinned to %s", entry.Version)
+	}
+
+	appPath := idx.Path(key)
+	if _, err := os.Stat(appPath); os.IsNotExist(err) {
+		return skip("directory missing (try 'kiosk rm %s')", key)
+	}
+
+	ctx, g := context.Background(), gitClient()
+	oldCommit, err := g.RevParse(ctx, appPath, "HEAD")
+	if err != nil {
+		return skip("failed to read HEAD: %v", err)
+	}
+	if err := g.Fetch(ctx, appPath); err != nil {
+		return skip("failed to fetch: %v", err)
+	}
+
+	ahead, behind, ok := g.AheadBehind(ctx, appPath)
+	switch {
+	case !ok:
+		return skip("no upstream branch")
+	case behind == 0:
+		result.status = "up to date"
+		return result
+	case ahead > 0:
+		return skip("local branch has diverged from upstream; resolve manually")
+	}
+
+	if status, err := g.Status(ctx, appPath); err == nil && status != "" {
+		return skip("local changes: %s", git.SummarizeStatus(status))
+	}
+
+	commits := "commit"
+	if behind != 1 {
+		commits = "commits"
+	}
+	if dryRun {
+		result.updated = true
+		result.status = fmt.Sprintf("would update (%d %s behind)", behind, commits)
+		return result
+	}
+
+	// Pull what was fetched above rather than fetching again
+	info, err := pullFetched(ctx, g, appPath, oldCommit, nil)
+	if err != nil {
+		return skip("%v", err)
+	}
+	if info == nil || !info.updated {
+		result.status = "up to date"
+		return result
+	}
+
+	result.updated = true
+	result.status = fmt.Sprintf("updated %s..%s (%d %s)", shortCommit(info.oldCommit), shortCommit(info.newCommit), behind, commits)
+	return result
+}
+
+func init() {
+	updateAppsCmd.Flags().BoolVar(&updateAppsAll, "all", false, "update every installed app")
+	updateAppsCmd.Flags().BoolVar(&updateAppsDryRun, "dry-run", false, "show which apps would be updated without pulling")
+	rootCmd.AddCommand(updateAppsCmd)
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
+	"github.com/reflective-technologies/kiosk-cli/internal/git"
+)
+
+func TestUpdateAppFetchesOnce(t *testing.T) {
+	runner := &scriptedGit{outputs: map[string][]string{
+		"rev-parse HEAD": {"aaa", "bbb"},
+		"rev-list --left-right --count HEAD...@{u}": {"0\t2", "0\t2"},
+	}}
+	defer func(orig func() *git.Git) { gitClient = orig }(gitClient)
+	gitClient = func() *git.Git { return &git.Git{Runner: runner} }
+
+	idx := &appindex.Index{Apps: map[string]*appindex.AppEntry{
+		"acme/tool": {GitUrl: "https://github.com/acme/tool", Path: t.TempDir()},
+	}}
+	result := updateApp(idx, "acme/tool", false)
+	if !result.updated || result.skipped {
+		t.Fatalf("updateApp() = %+v, want updated", result)
+	}
+
+	var fetches int
+	for _, call := range runner.calls {
+		if call == "fetch --quiet" {
+			fetches++
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("fetched %d times, want once; git calls = %q", fetches, runner.calls)
+	}
+}
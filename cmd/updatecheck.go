@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	"golang.org/x/term"
+)
+
+const (
+	// updateCheckInterval is how often kiosk looks for a new release
+	updateCheckInterval = 24 * time.Hour
+	// updateCheckTimeout bounds the background request so it can't hold
+	// up a command
+	updateCheckTimeout = 3 * time.Second
+)
+
+// updateCheckResult carries the latest release version back from the
+// background check
+var updateCheckResult chan string
+
+// updateCheckActive is set when startUpdateCheck decided this command
+// should report a new release
+var updateCheckActive bool
+
+// startUpdateCheck looks up the latest release in the background, at most
+// once per updateCheckInterval. finishUpdateCheck reports the result.
+func startUpdateCheck() {
+	if quiet || Version == "dev" || !term.IsTerminal(int(os.Stderr.Fd())) {
+		return
+	}
+	if cmd, _, err := rootCmd.Find(os.Args[1:]); err == nil && (cmd == updateCmd || cmd == versionCmd || cmd == resetCmd) {
+		return
+	}
+	if config.IsFreshInstall() {
+		return
+	}
+	cfg, err := config.Load()
+	if err != nil || cfg.Updates.DisableCheck {
+		return
+	}
+	updateCheckActive = true
+
+	if time.Since(cfg.Updates.LastChecked) < updateCheckInterval {
+		return
+	}
+	// Stamped now rather than when the check finishes, which a command that
+	// exits first never sees, so short commands don't check on every run
+	err = config.Update(func(cfg *config.Config) {
+		cfg.Updates.LastChecked = time.Now()
+	})
+	if err != nil {
+		return
+	}
+	updateCheckResult = make(chan string, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+		defer cancel()
+		latest, err := fetchLatestVersion(ctx)
+		if err != nil {
+			latest = ""
+		}
+		updateCheckResult <- latest
+	}()
+}
+
+// finishUpdateCheck records the result of a completed check and prints a
+// one-line notice if a newer release is known. It never waits for a check
+// still in flight.
+func finishUpdateCheck() {
+	if !updateCheckActive {
+		return
+	}
+	// Saving the result would bring back a config kiosk reset removed
+	if _, err := os.Stat(config.ConfigPath()); err != nil {
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	if updateCheckResult != nil {
+		select {
+		case latest := <-updateCheckResult:
+			if latest != "" {
+				cfg.Updates.LatestVersion = latest
+				_ = config.Update(func(saved *config.Config) {
+					saved.Updates.LatestVersion = latest
+				})
+			}
+		default:
+		}
+	}
+
+	latest := cfg.Updates.LatestVersion
+	if cmp, ok := compareVersions(latest, Version); ok && cmp > 0 {
+		fmt.Fprintf(os.Stderr, "kiosk %s is available; run kiosk update\n", latest)
+	}
+}
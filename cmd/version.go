@@ -1,7 +1,12 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -9,16 +14,194 @@ import (
 var (
 	// Version is set at build time via ldflags
 	Version = "dev"
+	// Commit is the git commit the binary was built from, set via ldflags
+	Commit = ""
+	// BuildDate is the RFC 3339 build timestamp, set via ldflags
+	BuildDate = ""
 )
 
+var versionJSON bool
+
+// buildInfo describes the running binary
+type buildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildDate string `json:"buildDate,omitempty"`
+	GoVersion string `json:"goVersion"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
+// currentBuildInfo returns the build metadata for this binary. Commit falls
+// back to the VCS stamp Go embeds when it wasn't set via ldflags (e.g. go
+// install or go build from a checkout).
+func currentBuildInfo() buildInfo {
+	info := buildInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			if s.Key == "vcs.revision" && info.Commit == "" {
+				info.Commit = s.Value
+			}
+		}
+	}
+
+	return info
+}
+
+// IsDev reports whether this is an unreleased development build
+func (b buildInfo) IsDev() bool {
+	_, ok := parseVersion(b.Version)
+	return !ok
+}
+
+// String returns the one-line human-readable form of b
+func (b buildInfo) String() string {
+	s := "kiosk version " + b.Version
+	if b.Commit != "" {
+		commit := b.Commit
+		if len(commit) > 12 {
+			commit = commit[:12]
+		}
+		s += " (" + commit
+		if b.BuildDate != "" {
+			s += ", built " + b.BuildDate
+		}
+		s += ")"
+	}
+	return s + fmt.Sprintf(" %s %s/%s", b.GoVersion, b.OS, b.Arch)
+}
+
+// semver is a parsed semantic version. Build metadata is dropped since it
+// doesn't affect precedence.
+type semver struct {
+	major, minor, patch int
+	pre                 []string // pre-release identifiers, e.g. ["rc", "1"]
+}
+
+// parseVersion parses a release version like v1.2.3, 1.2 or 1.2.3-rc.1.
+// It reports false for anything else, including dev builds.
+func parseVersion(v string) (semver, bool) {
+	var sv semver
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.Index(v, "+"); i >= 0 {
+		v = v[:i]
+	}
+	if i := strings.Index(v, "-"); i >= 0 {
+		sv.pre = strings.Split(v[i+1:], ".")
+		v = v[:i]
+		for _, id := range sv.pre {
+			if id == "" {
+				return sv, false
+			}
+		}
+	}
+
+	fields := strings.Split(v, ".")
+	if len(fields) > 3 {
+		return sv, false
+	}
+	nums := []*int{&sv.major, &sv.minor, &sv.patch}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return sv, false
+		}
+		*nums[i] = n
+	}
+	return sv, true
+}
+
+// compare returns -1, 0 or 1 depending on whether v is older than, the same
+// as, or newer than o, following semver precedence: a pre-release sorts
+// before its release, and numeric identifiers sort before alphanumeric ones.
+func (v semver) compare(o semver) int {
+	for _, p := range [][2]int{{v.major, o.major}, {v.minor, o.minor}, {v.patch, o.patch}} {
+		if c := cmpInt(p[0], p[1]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(v.pre) == 0 && len(o.pre) == 0:
+		return 0
+	case len(v.pre) == 0:
+		return 1
+	case len(o.pre) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.pre) && i < len(o.pre); i++ {
+		a, b := v.pre[i], o.pre[i]
+		na, errA := strconv.Atoi(a)
+		nb, errB := strconv.Atoi(b)
+		var c int
+		switch {
+		case errA == nil && errB == nil:
+			c = cmpInt(na, nb)
+		case errA == nil:
+			c = -1
+		case errB == nil:
+			c = 1
+		default:
+			c = strings.Compare(a, b)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmpInt(len(v.pre), len(o.pre))
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareVersions returns -1, 0 or 1 depending on whether a is older than,
+// the same as, or newer than b. ok is false if either isn't a release version.
+func compareVersions(a, b string) (cmp int, ok bool) {
+	va, okA := parseVersion(a)
+	vb, okB := parseVersion(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	return va.compare(vb), true
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the version number",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("kiosk version %s\n", Version)
+	Long: `Print the kiosk version along with the git commit, build date,
+Go version and platform. Include this output when reporting bugs.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info := currentBuildInfo()
+		if versionJSON {
+			data, err := json.MarshalIndent(info, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode version info: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		fmt.Println(info.String())
+		return nil
 	},
 }
 
 func init() {
+	versionCmd.Flags().BoolVar(&versionJSON, "json", false, "print build info as JSON")
 	rootCmd.AddCommand(versionCmd)
 }
@@ -0,0 +1,31 @@
+package cmd
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   string
+		want   int
+		wantOK bool
+	}{
+		{name: "equal with v prefix", a: "1.2.3", b: "v1.2.3", want: 0, wantOK: true},
+		{name: "older patch", a: "v1.2.3", b: "v1.2.4", want: -1, wantOK: true},
+		{name: "newer minor", a: "v1.10.0", b: "v1.9.9", want: 1, wantOK: true},
+		{name: "missing patch", a: "v1.2", b: "v1.2.0", want: 0, wantOK: true},
+		{name: "pre-release before release", a: "v1.2.0-rc.1", b: "v1.2.0", want: -1, wantOK: true},
+		{name: "numeric pre-release", a: "v1.2.0-rc.2", b: "v1.2.0-rc.10", want: -1, wantOK: true},
+		{name: "longer pre-release wins", a: "v1.2.0-rc.1.1", b: "v1.2.0-rc.1", want: 1, wantOK: true},
+		{name: "build metadata ignored", a: "v1.2.0+abc", b: "v1.2.0", want: 0, wantOK: true},
+		{name: "dev build", a: "dev", b: "v1.2.0", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := compareVersions(tt.a, tt.b)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("compareVersions(%q, %q) = %d, %v; want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
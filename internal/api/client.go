@@ -2,7 +2,9 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,7 +12,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/reflective-technologies/kiosk-cli/internal/auth"
 	apierrors "github.com/reflective-technologies/kiosk-cli/internal/errors"
+	"github.com/reflective-technologies/kiosk-cli/internal/giturl"
 )
 
 // Client is a kiosk API client
@@ -18,6 +22,10 @@ type Client struct {
 	BaseURL    string
 	HTTPClient *http.Client
 	token      string // GitHub access token for authenticated requests
+
+	// Alternate source for app metadata; see WithRegistry
+	registryURL string
+	registryDir string
 }
 
 // Creator represents the app creator from the API
@@ -29,6 +37,16 @@ type Creator struct {
 	AvatarURL string `json:"avatarUrl,omitempty"`
 }
 
+// User represents the authenticated user as the API sees them
+type User struct {
+	ID        string `json:"id"`
+	GithubID  int    `json:"githubId,omitempty"`
+	Username  string `json:"username"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	AvatarURL string `json:"avatarUrl,omitempty"`
+}
+
 // App represents an app from the API
 type App struct {
 	ID           string   `json:"id"`
@@ -78,24 +96,94 @@ type PaginatedAppsResponse struct {
 // NewClient creates a new API client without authentication
 func NewClient(baseURL string) *Client {
 	return &Client{
-		BaseURL: strings.TrimSuffix(baseURL, "/"),
-		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		BaseURL:    NormalizeBaseURL(baseURL),
+		HTTPClient: newHTTPClient(),
 	}
 }
 
 // NewAuthenticatedClient creates a new API client with GitHub token authentication
 func NewAuthenticatedClient(baseURL, token string) *Client {
 	return &Client{
-		BaseURL: strings.TrimSuffix(baseURL, "/"),
-		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+		BaseURL:    NormalizeBaseURL(baseURL),
+		HTTPClient: newHTTPClient(),
+		token:      token,
+	}
+}
+
+// maxRedirects matches the net/http default
+const maxRedirects = 10
+
+// newHTTPClient returns an http.Client that keeps the bearer token on
+// same-host redirects and refuses to follow authenticated requests to
+// another host or scheme, e.g. https to http, so the token is never sent
+// somewhere it wasn't meant for.
+func newHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+
+			authHeader := via[0].Header.Get("Authorization")
+			if authHeader == "" {
+				return nil
+			}
+			if !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+				return fmt.Errorf("refusing to follow authenticated redirect from %s to %s; update apiUrl to the new host", via[0].URL.Host, req.URL.Host)
+			}
+			if req.URL.Scheme != via[0].URL.Scheme {
+				return fmt.Errorf("refusing to follow authenticated redirect from %s to %s", via[0].URL.Scheme, req.URL.Scheme)
+			}
+			req.Header.Set("Authorization", authHeader)
+			return nil
 		},
-		token: token,
 	}
 }
 
+// NormalizeBaseURL reduces an API URL to scheme://host[/base/path] with no
+// trailing slash, query, or fragment. A missing scheme defaults to https,
+// and a trailing /api is dropped since endpoint paths already include it.
+func NormalizeBaseURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return raw
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return strings.TrimRight(raw, "/")
+	}
+
+	path := strings.TrimRight(u.Path, "/")
+	path = strings.TrimSuffix(path, "/api")
+
+	return u.Scheme + "://" + u.Host + path
+}
+
+// NewClientFromCreds creates a new API client that authenticates with the
+// stored credentials when the user is logged in, and anonymously otherwise
+func NewClientFromCreds(baseURL string) *Client {
+	c := NewClient(baseURL)
+	if token, err := auth.GetToken(); err == nil {
+		c.token = token
+	}
+	return c
+}
+
+// NewOriginClient returns a client for apiURL, the API an app was installed
+// from, that carries the stored credentials only if apiURL is configuredURL,
+// the API they were issued for
+func NewOriginClient(apiURL, configuredURL string) *Client {
+	if NormalizeBaseURL(apiURL) == NormalizeBaseURL(configuredURL) {
+		return NewClientFromCreds(apiURL)
+	}
+	return NewClient(apiURL)
+}
+
 // SetToken sets the authentication token for the client
 func (c *Client) SetToken(token string) {
 	c.token = token
@@ -126,7 +214,7 @@ func wrapNetworkError(err error) error {
 			return apierrors.NewNetworkError("Could not reach the Kiosk API (DNS lookup failed)", err)
 		case strings.Contains(urlErr.Error(), "connection refused"):
 			return apierrors.NewNetworkError("Could not connect to the Kiosk API (connection refused)", err)
-		case strings.Contains(urlErr.Error(), "timeout"):
+		case strings.Contains(urlErr.Error(), "timeout") || errors.Is(err, context.DeadlineExceeded):
 			return apierrors.NewNetworkError("Request to Kiosk API timed out", err)
 		case strings.Contains(urlErr.Error(), "certificate"):
 			return apierrors.NewNetworkError("SSL/TLS certificate error when connecting to Kiosk API", err)
@@ -159,9 +247,33 @@ func (c *Client) doAuthenticatedRequest(req *http.Request) (*http.Response, erro
 // GetApp fetches app metadata by ID.
 // ID can be either "appId" or "org/repo" format.
 // When "org/repo" format is used, only the repo name is extracted as the appId.
-// Note: This means different orgs with same-named repos would resolve to the same app.
-// This matches the Kiosk API behavior where apps are identified by repo name alone.
+// The Kiosk API identifies apps by repo name alone, so different orgs with
+// same-named repos resolve to the same app; GetApp returns an error rather
+// than another org's app when they differ.
 func (c *Client) GetApp(id string) (*App, error) {
+	app, err := c.fetchApp(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.Contains(id, "/") {
+		if orgRepo := giturl.ExtractOrgRepo(app.GitUrl); orgRepo != "" && !strings.EqualFold(orgRepo, id) {
+			return nil, fmt.Errorf("%s is not on Kiosk; the app named %s is %s", id, app.ID, orgRepo)
+		}
+	}
+	return app, nil
+}
+
+// fetchApp fetches app metadata by ID without checking the org
+func (c *Client) fetchApp(id string) (*App, error) {
+	if c.registryDir != "" {
+		app, err := c.registryLookup(id)
+		if err != nil {
+			return nil, err
+		}
+		return &app.App, nil
+	}
+
 	appId := id
 	if strings.Contains(id, "/") {
 		parts := strings.SplitN(id, "/", 2)
@@ -170,13 +282,13 @@ func (c *Client) GetApp(id string) (*App, error) {
 		}
 	}
 
-	reqURL := fmt.Sprintf("%s/api/kiosk/%s", c.BaseURL, appId)
+	reqURL := fmt.Sprintf("%s/api/kiosk/%s", c.readBaseURL(), appId)
 	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.doRequest(req)
+	resp, err := c.doReadRequest(req)
 	if err != nil {
 		return nil, err
 	}
@@ -187,7 +299,7 @@ func (c *Client) GetApp(id string) (*App, error) {
 	}
 
 	var app App
-	if err := json.NewDecoder(resp.Body).Decode(&app); err != nil {
+	if err := decodeJSON(resp.Body, &app); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -197,6 +309,10 @@ func (c *Client) GetApp(id string) (*App, error) {
 // GetInstallPrompt fetches the installation prompt for an app.
 // ID can be either "appId" or "org/repo" format (see GetApp for details).
 func (c *Client) GetInstallPrompt(id string) (string, error) {
+	if c.registryDir != "" {
+		return c.registryInstall(id)
+	}
+
 	appId := id
 	if strings.Contains(id, "/") {
 		parts := strings.SplitN(id, "/", 2)
@@ -205,13 +321,13 @@ func (c *Client) GetInstallPrompt(id string) (string, error) {
 		}
 	}
 
-	reqURL := fmt.Sprintf("%s/api/kiosk/%s/install", c.BaseURL, appId)
+	reqURL := fmt.Sprintf("%s/api/kiosk/%s/install", c.readBaseURL(), appId)
 	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.doRequest(req)
+	resp, err := c.doReadRequest(req)
 	if err != nil {
 		return "", err
 	}
@@ -283,13 +399,17 @@ func (c *Client) GetPublishPrompt() (string, error) {
 
 // ListApps fetches all published apps (legacy, non-paginated)
 func (c *Client) ListApps() ([]App, error) {
-	reqURL := fmt.Sprintf("%s/api/kiosk", c.BaseURL)
+	if c.registryDir != "" {
+		return c.registryApps()
+	}
+
+	reqURL := fmt.Sprintf("%s/api/kiosk", c.readBaseURL())
 	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.doRequest(req)
+	resp, err := c.doReadRequest(req)
 	if err != nil {
 		return nil, err
 	}
@@ -300,28 +420,66 @@ func (c *Client) ListApps() ([]App, error) {
 	}
 
 	var apps []App
-	if err := json.NewDecoder(resp.Body).Decode(&apps); err != nil {
+	if err := decodeJSON(resp.Body, &apps); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	return apps, nil
 }
 
+// ListAppsByCreator fetches the apps published by the user with the given
+// GitHub username. The API can't filter by creator, so this filters the full
+// list.
+func (c *Client) ListAppsByCreator(username string) ([]App, error) {
+	apps, err := c.ListApps()
+	if err != nil {
+		return nil, err
+	}
+	return FilterCreator(apps, username), nil
+}
+
+// FilterCreator returns the apps published by username, ignoring case and
+// a leading @. An empty username returns apps unchanged.
+func FilterCreator(apps []App, username string) []App {
+	username = strings.TrimPrefix(username, "@")
+	if username == "" {
+		return apps
+	}
+
+	result := make([]App, 0, len(apps))
+	for _, app := range apps {
+		if app.Creator != nil && strings.EqualFold(app.Creator.Username, username) {
+			result = append(result, app)
+		}
+	}
+	return result
+}
+
 // ListAppsPaginated fetches apps with pagination support.
 // limit specifies the number of apps per page.
 // cursor is the pagination cursor (empty string for first page).
 func (c *Client) ListAppsPaginated(limit int, cursor string) (*PaginatedAppsResponse, error) {
-	reqURL := fmt.Sprintf("%s/api/kiosk?paginated=true&limit=%d", c.BaseURL, limit)
+	return c.ListAppsPaginatedContext(context.Background(), limit, cursor)
+}
+
+// ListAppsPaginatedContext is ListAppsPaginated with a context that can
+// cancel the request.
+func (c *Client) ListAppsPaginatedContext(ctx context.Context, limit int, cursor string) (*PaginatedAppsResponse, error) {
+	if c.registryDir != "" {
+		return c.registryPage(limit, cursor)
+	}
+
+	reqURL := fmt.Sprintf("%s/api/kiosk?paginated=true&limit=%d", c.readBaseURL(), limit)
 	if cursor != "" {
 		reqURL += "&cursor=" + url.QueryEscape(cursor)
 	}
 
-	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.doRequest(req)
+	resp, err := c.doReadRequest(req)
 	if err != nil {
 		return nil, err
 	}
@@ -332,7 +490,7 @@ func (c *Client) ListAppsPaginated(limit int, cursor string) (*PaginatedAppsResp
 	}
 
 	var result PaginatedAppsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := decodeJSON(resp.Body, &result); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -365,7 +523,7 @@ func (c *Client) CreateApp(req CreateAppRequest) (*App, error) {
 	}
 
 	var app App
-	if err := json.NewDecoder(resp.Body).Decode(&app); err != nil {
+	if err := decodeJSON(resp.Body, &app); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -398,7 +556,7 @@ func (c *Client) UpdateApp(id string, req UpdateAppRequest) (*App, error) {
 	}
 
 	var app App
-	if err := json.NewDecoder(resp.Body).Decode(&app); err != nil {
+	if err := decodeJSON(resp.Body, &app); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -449,3 +607,75 @@ func (c *Client) RefreshApp(id string) error {
 
 	return nil
 }
+
+// GetCurrentUser fetches the user the client's token belongs to (requires
+// authentication). Unlike the locally stored credentials, this confirms the
+// server still accepts the token.
+func (c *Client) GetCurrentUser() (*User, error) {
+	reqURL := fmt.Sprintf("%s/api/auth/me", c.BaseURL)
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.doAuthenticatedRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, handleAPIError(resp)
+	}
+
+	var user User
+	if err := decodeJSON(resp.Body, &user); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &user, nil
+}
+
+// ErrReportingUnsupported is returned by ReportApp when the server doesn't
+// accept reports
+var ErrReportingUnsupported = errors.New("reporting apps is not supported by this server")
+
+// ReportApp flags an app for review with the reason given (requires authentication).
+// ID can be either "appId" or "org/repo" format (see GetApp for details).
+func (c *Client) ReportApp(id, reason string) error {
+	appId := id
+	if strings.Contains(id, "/") {
+		parts := strings.SplitN(id, "/", 2)
+		if len(parts) == 2 {
+			appId = parts[1]
+		}
+	}
+
+	body, err := json.Marshal(struct {
+		Reason string `json:"reason"`
+	}{Reason: reason})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	reqURL := fmt.Sprintf("%s/api/kiosk/%s/report", c.BaseURL, appId)
+	httpReq, err := http.NewRequest(http.MethodPost, reqURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.doAuthenticatedRequest(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return ErrReportingUnsupported
+	}
+	return handleAPIError(resp)
+}
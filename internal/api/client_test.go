@@ -0,0 +1,313 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/auth"
+)
+
+func TestNormalizeBaseURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "already normalized",
+			input: "https://kiosk.app",
+			want:  "https://kiosk.app",
+		},
+		{
+			name:  "trailing slash",
+			input: "https://kiosk.app/",
+			want:  "https://kiosk.app",
+		},
+		{
+			name:  "missing scheme",
+			input: "kiosk.app",
+			want:  "https://kiosk.app",
+		},
+		{
+			name:  "base path kept",
+			input: "https://example.com/kiosk/",
+			want:  "https://example.com/kiosk",
+		},
+		{
+			name:  "trailing api dropped",
+			input: "https://kiosk.app/api/",
+			want:  "https://kiosk.app",
+		},
+		{
+			name:  "query and fragment dropped",
+			input: "http://localhost:3000/?debug=1#top",
+			want:  "http://localhost:3000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeBaseURL(tt.input); got != tt.want {
+				t.Errorf("NormalizeBaseURL(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedirectKeepsTokenOnSameHost(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old/api/kiosk" {
+			http.Redirect(w, r, "/api/kiosk", http.StatusPermanentRedirect)
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	client := NewAuthenticatedClient(srv.URL+"/old", "secret")
+	if _, err := client.ListApps(); err != nil {
+		t.Fatalf("ListApps() error = %v", err)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("Authorization after redirect = %q, want %q", gotAuth, "Bearer secret")
+	}
+}
+
+func TestRedirectToOtherHostRejectedWhenAuthenticated(t *testing.T) {
+	var leaked bool
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = true
+		w.Write([]byte(`[]`))
+	}))
+	defer other.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, other.URL+r.URL.Path, http.StatusMovedPermanently)
+	}))
+	defer srv.Close()
+
+	client := NewAuthenticatedClient(srv.URL, "secret")
+	if _, err := client.ListApps(); err == nil {
+		t.Fatal("ListApps() error = nil, want redirect refused")
+	}
+	if leaked {
+		t.Error("request with token reached the other host")
+	}
+
+	// Anonymous requests have nothing to leak and may follow the redirect
+	if _, err := NewClient(srv.URL).ListApps(); err != nil {
+		t.Errorf("anonymous ListApps() error = %v", err)
+	}
+}
+
+func TestRedirectToHTTPRejectedWhenAuthenticated(t *testing.T) {
+	from, _ := http.NewRequest(http.MethodGet, "https://kiosk.example.com/api/kiosk", nil)
+	from.Header.Set("Authorization", "Bearer secret")
+	to, _ := http.NewRequest(http.MethodGet, "http://kiosk.example.com/api/kiosk", nil)
+
+	if err := newHTTPClient().CheckRedirect(to, []*http.Request{from}); err == nil {
+		t.Fatal("CheckRedirect() error = nil, want redirect from https to http refused")
+	}
+	if got := to.Header.Get("Authorization"); got != "" {
+		t.Errorf("Authorization on the http request = %q, want none", got)
+	}
+}
+
+func TestNewOriginClientOnlySendsTokenToConfiguredAPI(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := auth.SaveCredentials(&auth.Credentials{AccessToken: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := NewOriginClient("https://kiosk.example.com/", "https://kiosk.example.com"); c.token != "secret" {
+		t.Errorf("client for the configured API has token %q, want %q", c.token, "secret")
+	}
+	if c := NewOriginClient("https://other.example.com", "https://kiosk.example.com"); c.token != "" {
+		t.Errorf("client for another API has token %q, want none", c.token)
+	}
+}
+
+func TestPing(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer up.Close()
+
+	if err := NewClient(up.URL).Ping(); err != nil {
+		t.Errorf("Ping() against a server without /api/health: error = %v, want nil", err)
+	}
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	if err := NewClient(down.URL).Ping(); err == nil {
+		t.Error("Ping() against an unavailable server: error = nil, want error")
+	}
+}
+
+func TestGetAppChecksOrg(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"widget","name":"Widget","gitUrl":"https://github.com/acme/widget"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	for _, id := range []string{"widget", "acme/widget", "Acme/Widget"} {
+		if _, err := client.GetApp(id); err != nil {
+			t.Errorf("GetApp(%q) error = %v", id, err)
+		}
+	}
+	if _, err := client.GetApp("globex/widget"); err == nil {
+		t.Error("GetApp(\"globex/widget\") returned acme's app, want error")
+	}
+}
+
+func TestReportAppStatus(t *testing.T) {
+	status := http.StatusNotFound
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.token = "secret"
+
+	if err := client.ReportApp("acme/widget", "spam"); !errors.Is(err, ErrReportingUnsupported) {
+		t.Errorf("ReportApp() on a 404: error = %v, want ErrReportingUnsupported", err)
+	}
+
+	status = http.StatusMethodNotAllowed
+	if err := client.ReportApp("acme/widget", "spam"); !errors.Is(err, ErrReportingUnsupported) {
+		t.Errorf("ReportApp() on a 405: error = %v, want ErrReportingUnsupported", err)
+	}
+}
+
+func TestAppInstallCountFormats(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "number", body: `{"id":"a","installCount":12}`, want: 12},
+		{name: "numeric string", body: `{"id":"a","installCount":"12345"}`, want: 12345},
+		{name: "null", body: `{"id":"a","installCount":null}`, want: 0},
+		{name: "missing", body: `{"id":"a"}`, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var app App
+			if err := decodeJSON(strings.NewReader(tt.body), &app); err != nil {
+				t.Fatalf("decodeJSON() error = %v", err)
+			}
+			if app.ID != "a" {
+				t.Errorf("ID = %q, want %q", app.ID, "a")
+			}
+			if app.InstallCount != tt.want {
+				t.Errorf("InstallCount = %d, want %d", app.InstallCount, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsedUpdatedAt(t *testing.T) {
+	tests := []struct {
+		name   string
+		app    App
+		want   string
+		wantOK bool
+	}{
+		{name: "updated", app: App{UpdatedAt: "2024-03-01T10:00:00Z", CreatedAt: "2024-01-01T00:00:00Z"}, want: "2024-03-01T10:00:00Z", wantOK: true},
+		{name: "fractional seconds", app: App{UpdatedAt: "2024-03-01T10:00:00.123Z"}, want: "2024-03-01T10:00:00.123Z", wantOK: true},
+		{name: "falls back to created", app: App{CreatedAt: "2024-01-01T00:00:00Z"}, want: "2024-01-01T00:00:00Z", wantOK: true},
+		{name: "malformed", app: App{UpdatedAt: "yesterday", CreatedAt: "2024-01-01T00:00:00Z"}, want: "2024-01-01T00:00:00Z", wantOK: true},
+		{name: "empty", app: App{}, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.app.ParsedUpdatedAt()
+			if ok != tt.wantOK {
+				t.Fatalf("ParsedUpdatedAt() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got.Format(time.RFC3339Nano) != tt.want {
+				t.Errorf("ParsedUpdatedAt() = %s, want %s", got.Format(time.RFC3339Nano), tt.want)
+			}
+		})
+	}
+}
+
+func TestUnknownFields(t *testing.T) {
+	var raw any
+	body := `{"apps":[{"id":"a","badge":"new"},{"id":"b","badge":"hot"}],"nextCursor":null,"total":2}`
+	if err := json.Unmarshal([]byte(body), &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	got := unknownFields(raw, reflect.TypeOf(&PaginatedAppsResponse{}), "")
+	sort.Strings(got)
+	want := []string{"apps[].badge", "total"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unknownFields() = %v, want %v", got, want)
+	}
+}
+
+func TestFileRegistry(t *testing.T) {
+	dir := t.TempDir()
+	descriptors := map[string]string{
+		"weather.json": `{"id":"weather","name":"Weather","gitUrl":"https://github.com/acme/weather","installCount":"3"}`,
+		"notes.json":   `{"name":"Notes","gitUrl":"https://github.com/acme/notes","installPrompt":"Set up notes."}`,
+	}
+	for name, body := range descriptors {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client := NewClient("https://kiosk.app").WithRegistry("file://" + dir)
+
+	page, err := client.ListAppsPaginated(1, "")
+	if err != nil {
+		t.Fatalf("ListAppsPaginated() error = %v", err)
+	}
+	if len(page.Apps) != 1 || page.Apps[0].ID != "notes" || page.NextCursor == nil {
+		t.Fatalf("first page = %+v, want notes with a next cursor", page)
+	}
+	page, err = client.ListAppsPaginated(1, *page.NextCursor)
+	if err != nil {
+		t.Fatalf("ListAppsPaginated() error = %v", err)
+	}
+	if len(page.Apps) != 1 || page.Apps[0].ID != "weather" || page.Apps[0].InstallCount != 3 || page.NextCursor != nil {
+		t.Fatalf("second page = %+v, want weather and no next cursor", page)
+	}
+
+	app, err := client.GetApp("acme/weather")
+	if err != nil || app.Name != "Weather" {
+		t.Errorf("GetApp(acme/weather) = %+v, %v; want Weather", app, err)
+	}
+	if _, err := client.GetApp("missing"); err == nil {
+		t.Error("GetApp(missing) error = nil, want error")
+	}
+
+	prompt, err := client.GetInstallPrompt("notes")
+	if err != nil || prompt != "Set up notes." {
+		t.Errorf("GetInstallPrompt(notes) = %q, %v; want the descriptor's prompt", prompt, err)
+	}
+	prompt, err = client.GetInstallPrompt("weather")
+	if err != nil || !strings.Contains(prompt, "KIOSK.md") {
+		t.Errorf("GetInstallPrompt(weather) = %q, %v; want the default prompt", prompt, err)
+	}
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Debug logs fields in API responses that the CLI doesn't know about to
+// stderr. It is set from $KIOSK_DEBUG, so output stays quiet by default.
+var Debug bool
+
+// decodeJSON decodes an API response body into v. Fields the CLI doesn't
+// know about are ignored so a newer server doesn't break older clients; with
+// Debug set they are logged to stderr so schema drift gets noticed.
+func decodeJSON(r io.Reader, v any) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	if Debug {
+		var raw any
+		if json.Unmarshal(data, &raw) == nil {
+			if unknown := unknownFields(raw, reflect.TypeOf(v), ""); len(unknown) > 0 {
+				sort.Strings(unknown)
+				fmt.Fprintf(os.Stderr, "debug: API response has unknown fields: %s\n", strings.Join(unknown, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// unknownFields returns the paths of object keys in raw that have no
+// matching field in t. Repeated paths from array elements are reported once.
+func unknownFields(raw any, t reflect.Type, path string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	seen := map[string]bool{}
+	var result []string
+	add := func(paths []string) {
+		for _, p := range paths {
+			if !seen[p] {
+				seen[p] = true
+				result = append(result, p)
+			}
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		items, ok := raw.([]any)
+		if !ok {
+			return nil
+		}
+		for _, item := range items {
+			add(unknownFields(item, t.Elem(), path+"[]"))
+		}
+
+	case reflect.Struct:
+		obj, ok := raw.(map[string]any)
+		if !ok {
+			return nil
+		}
+		fields := jsonFields(t)
+		for name, value := range obj {
+			fieldPath := name
+			if path != "" {
+				fieldPath = path + "." + name
+			}
+			field, ok := fields[strings.ToLower(name)]
+			if !ok {
+				add([]string{fieldPath})
+				continue
+			}
+			add(unknownFields(value, field.Type, fieldPath))
+		}
+	}
+	return result
+}
+
+// jsonFields maps the lowercased JSON names of t's exported fields to the
+// fields, matching encoding/json's case-insensitive key lookup
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag := f.Tag.Get("json"); tag != "" {
+			if tag == "-" {
+				continue
+			}
+			if n, _, _ := strings.Cut(tag, ","); n != "" {
+				name = n
+			}
+		}
+		fields[strings.ToLower(name)] = f
+	}
+	return fields
+}
+
+// flexInt is an int that also accepts numeric strings and null in JSON,
+// for counts the server may send in either form
+type flexInt int
+
+func (n *flexInt) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(data))
+	if s == "null" {
+		*n = 0
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = strings.TrimSpace(unquoted)
+		if s == "" {
+			*n = 0
+			return nil
+		}
+	}
+
+	if i, err := strconv.Atoi(s); err == nil {
+		*n = flexInt(i)
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid count %s", string(data))
+	}
+	*n = flexInt(f)
+	return nil
+}
+
+// UnmarshalJSON decodes an App, accepting installCount as a number or a
+// numeric string
+func (a *App) UnmarshalJSON(data []byte) error {
+	type appFields App
+	aux := struct {
+		*appFields
+		InstallCount flexInt `json:"installCount,omitempty"`
+	}{appFields: (*appFields)(a)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	a.InstallCount = int(aux.InstallCount)
+	return nil
+}
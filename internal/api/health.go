@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	apierrors "github.com/reflective-technologies/kiosk-cli/internal/errors"
+)
+
+// PingTimeout bounds how long Ping waits for the API to answer
+const PingTimeout = 5 * time.Second
+
+// pingCacheTTL is how long a Ping result is reused for the same base URL
+const pingCacheTTL = 30 * time.Second
+
+type pingResult struct {
+	err error
+	at  time.Time
+}
+
+var (
+	pingMu    sync.Mutex
+	pingCache = map[string]pingResult{}
+)
+
+// Ping checks that the Kiosk API is reachable, so commands can fail fast
+// with a clear message instead of a network error partway through. Any HTTP
+// response short of a server error counts as reachable. Results are cached
+// briefly per base URL.
+func (c *Client) Ping() error {
+	pingMu.Lock()
+	if r, ok := pingCache[c.BaseURL]; ok && time.Since(r.at) < pingCacheTTL {
+		pingMu.Unlock()
+		return r.err
+	}
+	pingMu.Unlock()
+
+	err := c.ping()
+
+	pingMu.Lock()
+	pingCache[c.BaseURL] = pingResult{err: err, at: time.Now()}
+	pingMu.Unlock()
+	return err
+}
+
+func (c *Client) ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), PingTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/api/health", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return wrapNetworkError(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return apierrors.NewNetworkError(fmt.Sprintf("The Kiosk API is unavailable (status %d)", resp.StatusCode), nil)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	apierrors "github.com/reflective-technologies/kiosk-cli/internal/errors"
+	"github.com/reflective-technologies/kiosk-cli/internal/giturl"
+)
+
+// WithRegistry makes the client read app metadata (ListApps, GetApp,
+// GetInstallPrompt) from registry instead of the Kiosk API, for mirrors in
+// air-gapped or internal setups. registry is either the URL of another
+// Kiosk-compatible API, or a file:// URL or path to a directory of JSON app
+// descriptors. Writes, auth, and prompts other than install still go to the
+// client's BaseURL. An empty registry leaves the client unchanged.
+func (c *Client) WithRegistry(registry string) *Client {
+	switch {
+	case registry == "":
+	case strings.HasPrefix(registry, "http://"), strings.HasPrefix(registry, "https://"):
+		c.registryURL = NormalizeBaseURL(registry)
+	default:
+		c.registryDir = registryPath(registry)
+	}
+	return c
+}
+
+// registryPath turns a file:// URL or plain path into a directory path
+func registryPath(registry string) string {
+	if strings.HasPrefix(registry, "file://") {
+		if u, err := url.Parse(registry); err == nil {
+			return filepath.FromSlash(u.Path)
+		}
+		return strings.TrimPrefix(registry, "file://")
+	}
+	return registry
+}
+
+// readBaseURL is the base URL for app metadata reads
+func (c *Client) readBaseURL() string {
+	if c.registryURL != "" {
+		return c.registryURL
+	}
+	return c.BaseURL
+}
+
+// doReadRequest performs a metadata read. Reads from an alternate registry
+// are anonymous so the Kiosk token is never sent to another server.
+func (c *Client) doReadRequest(req *http.Request) (*http.Response, error) {
+	if c.registryURL == "" {
+		return c.doRequest(req)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, wrapNetworkError(err)
+	}
+	return resp, nil
+}
+
+// registryApp is an app descriptor in a file registry: the API's app JSON,
+// optionally with the install prompt to hand Claude
+type registryApp struct {
+	App
+	InstallPrompt string `json:"installPrompt,omitempty"`
+}
+
+// UnmarshalJSON decodes the app fields with App's own decoding, which the
+// embedded App would otherwise apply to the whole descriptor
+func (r *registryApp) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &r.App); err != nil {
+		return err
+	}
+	var extra struct {
+		InstallPrompt string `json:"installPrompt"`
+	}
+	if err := json.Unmarshal(data, &extra); err != nil {
+		return err
+	}
+	r.InstallPrompt = extra.InstallPrompt
+	return nil
+}
+
+// registryInstallPrompt is used for file registry apps that don't provide
+// their own install prompt
+const registryInstallPrompt = `This directory contains the app %s, cloned from %s.
+Read its KIOSK.md and follow the instructions there to install and set up the app.`
+
+// loadRegistry reads every *.json descriptor in the registry directory,
+// sorted by app name. An app without an id takes its file name.
+func (c *Client) loadRegistry() ([]registryApp, error) {
+	if _, err := os.Stat(c.registryDir); err != nil {
+		return nil, fmt.Errorf("failed to read registry: %w", err)
+	}
+	files, err := filepath.Glob(filepath.Join(c.registryDir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry: %w", err)
+	}
+
+	apps := make([]registryApp, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read registry: %w", err)
+		}
+		var app registryApp
+		if err := json.Unmarshal(data, &app); err != nil {
+			return nil, fmt.Errorf("invalid app descriptor %s: %w", filepath.Base(file), err)
+		}
+		if app.ID == "" {
+			app.ID = strings.TrimSuffix(filepath.Base(file), ".json")
+		}
+		apps = append(apps, app)
+	}
+
+	sort.Slice(apps, func(i, j int) bool {
+		if apps[i].Name != apps[j].Name {
+			return apps[i].Name < apps[j].Name
+		}
+		return apps[i].ID < apps[j].ID
+	})
+	return apps, nil
+}
+
+// registryLookup finds an app in the file registry by ID, or by the org/repo
+// of its git URL
+func (c *Client) registryLookup(id string) (*registryApp, error) {
+	apps, err := c.loadRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	appID := id
+	if _, repo, ok := strings.Cut(id, "/"); ok {
+		appID = repo
+	}
+	for i := range apps {
+		if apps[i].ID == appID || strings.EqualFold(giturl.ExtractOrgRepo(apps[i].GitUrl), id) {
+			return &apps[i], nil
+		}
+	}
+	body, _ := json.Marshal(map[string]string{"error": fmt.Sprintf("App %s is not in the registry", id)})
+	return nil, apierrors.NewAPIError(http.StatusNotFound, body)
+}
+
+// registryApps lists the apps in the file registry
+func (c *Client) registryApps() ([]App, error) {
+	entries, err := c.loadRegistry()
+	if err != nil {
+		return nil, err
+	}
+	apps := make([]App, len(entries))
+	for i, entry := range entries {
+		apps[i] = entry.App
+	}
+	return apps, nil
+}
+
+// registryPage returns one page of the file registry. The cursor is the
+// offset of the page's first app.
+func (c *Client) registryPage(limit int, cursor string) (*PaginatedAppsResponse, error) {
+	apps, err := c.registryApps()
+	if err != nil {
+		return nil, err
+	}
+
+	start := 0
+	if cursor != "" {
+		if start, err = strconv.Atoi(cursor); err != nil || start < 0 {
+			return nil, fmt.Errorf("invalid registry cursor %q", cursor)
+		}
+	}
+	start = min(start, len(apps))
+	end := len(apps)
+	if limit > 0 {
+		end = min(start+limit, len(apps))
+	}
+
+	result := &PaginatedAppsResponse{Apps: apps[start:end]}
+	if end < len(apps) {
+		next := strconv.Itoa(end)
+		result.NextCursor = &next
+	}
+	return result, nil
+}
+
+// registryInstall returns the install prompt for an app in the file registry
+func (c *Client) registryInstall(id string) (string, error) {
+	app, err := c.registryLookup(id)
+	if err != nil {
+		return "", err
+	}
+	if app.InstallPrompt != "" {
+		return app.InstallPrompt, nil
+	}
+	return fmt.Sprintf(registryInstallPrompt, app.Name, app.GitUrl), nil
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sinceLayouts are the absolute date formats accepted by ParseSince
+var sinceLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+	"Jan 2 2006",
+	"Jan 2, 2006",
+	"2 Jan 2006",
+}
+
+// ParseSince parses a date for filtering apps. It accepts absolute dates
+// (2024-01-31, 2024/01/31, Jan 31 2024, RFC 3339) and relative durations
+// counted back from now (36h, 7d, 2w).
+func ParseSince(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+
+	for _, layout := range sinceLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	if d, ok := parseRelative(s); ok {
+		return time.Now().Add(-d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q (try 2024-01-31 or 7d)", s)
+}
+
+func parseRelative(s string) (time.Duration, bool) {
+	if len(s) < 2 {
+		return 0, false
+	}
+
+	var unit time.Duration
+	switch s[len(s)-1] {
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	default:
+		d, err := time.ParseDuration(s)
+		return d, err == nil && d > 0
+	}
+
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return time.Duration(n) * unit, true
+}
+
+// parseTimestamp parses an RFC 3339 timestamp from the API. ok is false for
+// empty or malformed values.
+func parseTimestamp(s string) (t time.Time, ok bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// ParsedUpdatedAt returns when the app was last updated, falling back to
+// when it was created. ok is false if neither timestamp is usable.
+func (a App) ParsedUpdatedAt() (t time.Time, ok bool) {
+	if t, ok := parseTimestamp(a.UpdatedAt); ok {
+		return t, true
+	}
+	return parseTimestamp(a.CreatedAt)
+}
+
+// ActiveSince reports whether the app was created or updated at or after t.
+// Apps without a parseable timestamp are excluded, since there's no way to
+// tell whether they're new.
+func (a App) ActiveSince(t time.Time) bool {
+	for _, ts := range []string{a.UpdatedAt, a.CreatedAt} {
+		if parsed, ok := parseTimestamp(ts); ok && !parsed.Before(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterSince returns the apps created or updated at or after t.
+// A zero t returns apps unchanged.
+func FilterSince(apps []App, t time.Time) []App {
+	if t.IsZero() {
+		return apps
+	}
+
+	result := make([]App, 0, len(apps))
+	for _, app := range apps {
+		if app.ActiveSince(t) {
+			result = append(result, app)
+		}
+	}
+	return result
+}
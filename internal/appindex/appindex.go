@@ -2,11 +2,16 @@ package appindex
 
 import (
 	"encoding/json"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	"github.com/reflective-technologies/kiosk-cli/internal/giturl"
 )
 
 // AppEntry represents a single installed app
@@ -16,6 +21,15 @@ type AppEntry struct {
 	GitUrl      string    `json:"gitUrl"`
 	InstalledAt time.Time `json:"installedAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
+	RunPrompt   string    `json:"runPrompt,omitempty"` // replaces the default run prompt when set
+	Version     string    `json:"version,omitempty"`   // git tag the app is pinned to; empty follows the default branch
+	Shallow     bool      `json:"shallow,omitempty"`   // cloned without full history
+	Path        string    `json:"path,omitempty"`      // absolute directory for apps installed outside the apps directory
+
+	// The Kiosk API and registry the app was installed from. Empty APIUrl
+	// means it predates these being recorded, so the configured ones apply.
+	APIUrl   string `json:"apiUrl,omitempty"`
+	Registry string `json:"registry,omitempty"`
 }
 
 // Index holds all installed apps
@@ -46,7 +60,12 @@ func Load() (*Index, error) {
 	}
 
 	if err := json.Unmarshal(data, idx); err != nil {
-		return nil, err
+		// Set the corrupt index aside and start empty rather than failing;
+		// installed apps can be re-registered by running them again
+		if err := config.RecoverCorruptFile(IndexPath(), err); err != nil {
+			return nil, err
+		}
+		return &Index{Apps: make(map[string]*AppEntry)}, nil
 	}
 
 	// Ensure Apps map is initialized
@@ -85,6 +104,65 @@ func (idx *Index) Add(key string, entry *AppEntry) {
 	idx.Apps[key] = entry
 }
 
+// Conflict is an index entry that an incoming app with the same key but
+// another repository would replace
+type Conflict struct {
+	Key      string
+	Existing *AppEntry
+	GitUrl   string // the incoming app's repository
+}
+
+// Conflict reports whether installing gitURL as key would replace an app
+// installed from a different repository, e.g. after an org collision or a
+// moved repo. It returns nil if key is free or holds the same repository.
+func (idx *Index) Conflict(key, gitURL string) *Conflict {
+	existing := idx.Get(key)
+	if existing == nil || existing.GitUrl == "" || gitURL == "" || giturl.Same(existing.GitUrl, gitURL) {
+		return nil
+	}
+	return &Conflict{Key: key, Existing: existing, GitUrl: gitURL}
+}
+
+// AddChecked adds entry like Add unless key holds an app from a different
+// repository, in which case it leaves the index alone and returns the
+// conflict. Re-adding the same repository keeps its original install time.
+func (idx *Index) AddChecked(key string, entry *AppEntry) *Conflict {
+	if conflict := idx.Conflict(key, entry.GitUrl); conflict != nil {
+		return conflict
+	}
+	if existing := idx.Get(key); existing != nil && entry.InstalledAt.IsZero() {
+		entry.InstalledAt = existing.InstalledAt
+	}
+	idx.Add(key, entry)
+	return nil
+}
+
+// Origin returns the Kiosk API URL and registry to use for the app
+// installed as key: the ones it was installed from, or the configured ones
+// if it isn't installed or its origin wasn't recorded
+func Origin(cfg *config.Config, key string) (apiURL, registry string) {
+	if idx, err := Load(); err == nil {
+		if entry := idx.Get(key); entry != nil && entry.APIUrl != "" {
+			return entry.APIUrl, entry.Registry
+		}
+	}
+	return cfg.APIUrl, cfg.Registry
+}
+
+// FindByGitURL returns the key of the installed app cloned from gitURL, or
+// "" if there is none
+func (idx *Index) FindByGitURL(gitURL string) string {
+	if gitURL == "" {
+		return ""
+	}
+	for _, key := range idx.List() {
+		if giturl.Same(gitURL, idx.Apps[key].GitUrl) {
+			return key
+		}
+	}
+	return ""
+}
+
 // Remove removes an app from the index
 func (idx *Index) Remove(key string) {
 	delete(idx.Apps, key)
@@ -96,12 +174,13 @@ func (idx *Index) Has(key string) bool {
 	return ok
 }
 
-// List returns all app keys
+// List returns all app keys, sorted
 func (idx *Index) List() []string {
 	keys := make([]string, 0, len(idx.Apps))
 	for k := range idx.Apps {
 		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 	return keys
 }
 
@@ -110,14 +189,89 @@ func (idx *Index) Count() int {
 	return len(idx.Apps)
 }
 
-// ValidateFilesystem checks if each app's directory exists
+// Path returns the directory an app key (org/repo) is installed in: the
+// one recorded in the index for apps installed elsewhere, and otherwise
+// its place in the apps directory
+func Path(key string) string {
+	if idx, err := Load(); err == nil {
+		return idx.Path(key)
+	}
+	return managedPath(key)
+}
+
+// Path returns the directory the app key is installed in, like the
+// package-level Path but without reading the index again
+func (idx *Index) Path(key string) string {
+	if entry := idx.Get(key); entry != nil && entry.Path != "" {
+		return entry.Path
+	}
+	return managedPath(key)
+}
+
+// managedPath returns where key lives in the apps directory
+func managedPath(key string) string {
+	org, repo, ok := strings.Cut(key, "/")
+	if !ok {
+		return filepath.Join(config.AppsDir(), key)
+	}
+	return config.AppPath(org, repo)
+}
+
+// Paths returns each app's directory keyed by app key. The map is a copy,
+// so it can be checked in the background while the index changes.
+func (idx *Index) Paths() map[string]string {
+	paths := make(map[string]string, len(idx.Apps))
+	for _, key := range idx.List() {
+		paths[key] = idx.Path(key)
+	}
+	return paths
+}
+
+// ValidatePaths checks which of the directories from Paths exist
 // Returns a map of key -> exists
-func (idx *Index) ValidateFilesystem() map[string]bool {
-	result := make(map[string]bool)
-	for key := range idx.Apps {
-		path := filepath.Join(config.AppsDir(), key)
+func ValidatePaths(paths map[string]string) map[string]bool {
+	result := make(map[string]bool, len(paths))
+	for key, path := range paths {
 		_, err := os.Stat(path)
 		result[key] = err == nil
 	}
 	return result
 }
+
+// DiskUsage returns the total size in bytes of an app's directory
+func DiskUsage(key string) (int64, error) {
+	return DirSize(Path(key))
+}
+
+// DirSize returns the total size in bytes of the regular files under dir
+func DirSize(dir string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			total += info.Size()
+		}
+		return nil
+	})
+	return total, err
+}
+
+// FormatSize formats a byte count for display, e.g. "12.3 MB"
+func FormatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,79 @@
+package appindex
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+)
+
+func TestAddChecked(t *testing.T) {
+	installed := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	idx := &Index{Apps: map[string]*AppEntry{
+		"acme/tool": {Name: "Tool", GitUrl: "https://github.com/acme/tool", InstalledAt: installed},
+	}}
+
+	conflict := idx.AddChecked("acme/tool", &AppEntry{Name: "Other", GitUrl: "https://github.com/acme-labs/tool"})
+	if conflict == nil {
+		t.Fatal("AddChecked with another repository returned no conflict")
+	}
+	if conflict.Existing.GitUrl != "https://github.com/acme/tool" || conflict.GitUrl != "https://github.com/acme-labs/tool" {
+		t.Errorf("conflict = %+v", conflict)
+	}
+	if got := idx.Get("acme/tool").Name; got != "Tool" {
+		t.Errorf("conflicting AddChecked replaced the entry with %q", got)
+	}
+
+	if conflict := idx.AddChecked("acme/tool", &AppEntry{GitUrl: "https://gitlab.com/acme/tool"}); conflict == nil {
+		t.Error("AddChecked with the same org/repo on another host returned no conflict")
+	}
+
+	if conflict := idx.AddChecked("acme/tool", &AppEntry{Name: "Tool 2", GitUrl: "git@github.com:acme/tool.git"}); conflict != nil {
+		t.Fatalf("AddChecked with the same repository returned conflict %+v", conflict)
+	}
+	entry := idx.Get("acme/tool")
+	if entry.Name != "Tool 2" || !entry.InstalledAt.Equal(installed) {
+		t.Errorf("after re-adding, entry = %+v; want the new name and the original install time", entry)
+	}
+
+	if conflict := idx.AddChecked("acme/new", &AppEntry{GitUrl: "https://github.com/acme/new"}); conflict != nil || !idx.Has("acme/new") {
+		t.Errorf("AddChecked for a new key: conflict %+v, added %v", conflict, idx.Has("acme/new"))
+	}
+}
+
+func TestPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(config.EnvConfig, filepath.Join(dir, "config.json"))
+	t.Setenv(config.EnvAppsDir, "")
+	custom := filepath.Join(dir, "projects", "tool")
+
+	idx := &Index{Apps: map[string]*AppEntry{
+		"acme/tool":    {GitUrl: "https://github.com/acme/tool", Path: custom},
+		"acme/managed": {GitUrl: "https://github.com/acme/managed"},
+	}}
+	if err := Save(idx); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{name: "custom path", key: "acme/tool", want: custom},
+		{name: "managed app", key: "acme/managed", want: filepath.Join(dir, "apps", "acme", "managed")},
+		{name: "not installed", key: "acme/other", want: filepath.Join(dir, "apps", "acme", "other")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := idx.Path(tt.key); got != tt.want {
+				t.Errorf("idx.Path(%q) = %s, want %s", tt.key, got, tt.want)
+			}
+			if got := Path(tt.key); got != tt.want {
+				t.Errorf("Path(%q) = %s, want %s", tt.key, got, tt.want)
+			}
+		})
+	}
+}
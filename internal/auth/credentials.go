@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
 )
 
 // UserInfo stores information about the authenticated user
@@ -28,11 +30,7 @@ type Credentials struct {
 
 // CredentialsPath returns the path to the credentials file
 func CredentialsPath() string {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return filepath.Join(".kiosk", "credentials.json")
-	}
-	return filepath.Join(home, ".kiosk", "credentials.json")
+	return filepath.Join(config.KioskDir(), "credentials.json")
 }
 
 // SaveCredentials saves the credentials to disk with secure permissions
@@ -68,7 +66,12 @@ func LoadCredentials() (*Credentials, error) {
 
 	var creds Credentials
 	if err := json.Unmarshal(data, &creds); err != nil {
-		return nil, fmt.Errorf("failed to parse credentials: %w", err)
+		// A truncated or corrupt file shouldn't wedge every command;
+		// set it aside and treat the user as logged out
+		if err := config.RecoverCorruptFile(CredentialsPath(), err); err != nil {
+			return nil, err
+		}
+		return nil, nil
 	}
 
 	return &creds, nil
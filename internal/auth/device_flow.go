@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -52,6 +53,10 @@ type TokenErrorResponse struct {
 type DeviceFlow struct {
 	BaseURL    string
 	HTTPClient *http.Client
+
+	// OnSlowDown, if set, is called from PollForAuth with the new polling
+	// interval whenever the server asks it to slow down
+	OnSlowDown func(interval time.Duration)
 }
 
 // NewDeviceFlow creates a new DeviceFlow instance
@@ -99,7 +104,8 @@ func (d *DeviceFlow) RequestDeviceCode() (*DeviceCodeResponse, error) {
 
 // PollForAuth polls Kiosk API for auth completion until the user authorizes or an error occurs.
 // timeout specifies how long to wait for authorization (use DefaultPollTimeout or 0 for default).
-func (d *DeviceFlow) PollForAuth(deviceCode string, interval int, timeout time.Duration) (*AuthResponse, error) {
+// Cancelling ctx stops polling immediately and returns ctx.Err().
+func (d *DeviceFlow) PollForAuth(ctx context.Context, deviceCode string, interval int, timeout time.Duration) (*AuthResponse, error) {
 	if timeout <= 0 {
 		timeout = DefaultPollTimeout
 	}
@@ -119,9 +125,13 @@ func (d *DeviceFlow) PollForAuth(deviceCode string, interval int, timeout time.D
 		}
 
 		// Wait before polling (this ensures we don't poll immediately)
-		time.Sleep(pollInterval)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
 
-		authResp, err := d.checkAuth(deviceCode)
+		authResp, err := d.checkAuth(ctx, deviceCode)
 		if err != nil {
 			// Check if it's a polling error we should handle
 			if pollErr, ok := err.(*PollError); ok {
@@ -131,7 +141,7 @@ func (d *DeviceFlow) PollForAuth(deviceCode string, interval int, timeout time.D
 					continue
 				case "slow_down":
 					// We're polling too fast, increase interval
-					pollInterval += 5 * time.Second
+					pollInterval = d.slowDown(pollInterval, pollErr.Interval)
 					continue
 				case "expired_token":
 					return nil, fmt.Errorf("device code expired, please run login again")
@@ -141,12 +151,15 @@ func (d *DeviceFlow) PollForAuth(deviceCode string, interval int, timeout time.D
 					// Check if it's a rate limit error (treat as slow_down)
 					if strings.Contains(strings.ToLower(pollErr.Code), "too many requests") ||
 						strings.Contains(strings.ToLower(pollErr.Description), "too many requests") {
-						pollInterval += 5 * time.Second
+						pollInterval = d.slowDown(pollInterval, pollErr.Interval)
 						continue
 					}
 					return nil, fmt.Errorf("%s: %s", pollErr.Code, pollErr.Description)
 				}
 			}
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
 			return nil, err
 		}
 
@@ -163,22 +176,37 @@ func (d *DeviceFlow) PollForAuth(deviceCode string, interval int, timeout time.D
 	}
 }
 
+// slowDown returns the polling interval after a slow_down response: 5s
+// more than before per RFC 8628, or the interval the server asked for if
+// that's longer
+func (d *DeviceFlow) slowDown(current time.Duration, serverInterval int) time.Duration {
+	next := current + 5*time.Second
+	if requested := time.Duration(serverInterval) * time.Second; requested > next {
+		next = requested
+	}
+	if d.OnSlowDown != nil {
+		d.OnSlowDown(next)
+	}
+	return next
+}
+
 // PollError represents a polling error from the token endpoint
 type PollError struct {
 	Code        string
 	Description string
+	Interval    int // seconds between polls the server asks for, if any
 }
 
 func (e *PollError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Description)
 }
 
-func (d *DeviceFlow) checkAuth(deviceCode string) (*AuthResponse, error) {
+func (d *DeviceFlow) checkAuth(ctx context.Context, deviceCode string) (*AuthResponse, error) {
 	params := url.Values{}
 	params.Set("device_code", deviceCode)
 	endpoint := fmt.Sprintf("%s/api/auth/github/device?%s", d.BaseURL, params.Encode())
 
-	req, err := http.NewRequest("GET", endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -199,9 +227,11 @@ func (d *DeviceFlow) checkAuth(deviceCode string) (*AuthResponse, error) {
 	// Check if there's an error in the response
 	if errCode, ok := rawResponse["error"].(string); ok {
 		errDesc, _ := rawResponse["error_description"].(string)
+		interval, _ := rawResponse["interval"].(float64)
 		return nil, &PollError{
 			Code:        errCode,
 			Description: errDesc,
+			Interval:    int(interval),
 		}
 	}
 
@@ -251,3 +281,42 @@ func (d *DeviceFlow) checkAuth(deviceCode string) (*AuthResponse, error) {
 
 	return authResp, nil
 }
+
+// FormatRemaining formats a countdown for display, e.g. "4m 05s" or "42s".
+func FormatRemaining(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Round(time.Second)
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	return fmt.Sprintf("%dm %02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// Countdown describes how long the user has left to enter the code: until
+// expiresAt, when the code expires (zero if unknown), or deadline, when
+// polling gives up, whichever comes first.
+func Countdown(now, expiresAt, deadline time.Time) string {
+	timeout := "gives up in " + FormatRemaining(deadline.Sub(now))
+	if expiresAt.IsZero() {
+		return "Login " + timeout
+	}
+	if !expiresAt.After(deadline) {
+		return "Code expires in " + FormatRemaining(expiresAt.Sub(now))
+	}
+	return "Code expires in " + FormatRemaining(expiresAt.Sub(now)) + ", login " + timeout
+}
+
+// SendSlowDowns returns an OnSlowDown callback that sends each new interval
+// on ch, which must have a buffer of one. An interval the reader hasn't
+// taken yet is replaced, so only the latest is kept.
+func SendSlowDowns(ch chan time.Duration) func(time.Duration) {
+	return func(interval time.Duration) {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- interval
+	}
+}
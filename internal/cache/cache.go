@@ -0,0 +1,105 @@
+// Package cache keeps short-lived copies of API responses under
+// ~/.kiosk/cache, so running the same app over and over doesn't refetch its
+// metadata every time.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+)
+
+// Store is a directory of cached values, one JSON file per key
+type Store struct {
+	Dir string
+	Now func() time.Time // defaults to time.Now
+}
+
+// entry is the on-disk form of a cached value
+type entry struct {
+	Key      string          `json:"key"`
+	StoredAt time.Time       `json:"storedAt"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// Default returns the store in ~/.kiosk/cache
+func Default() *Store {
+	return &Store{Dir: config.CacheDir()}
+}
+
+func (s *Store) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// path returns the file for key. Keys are hashed since they may hold URLs
+// and other characters that aren't safe in file names.
+func (s *Store) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.Dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+// Get decodes the value stored under key into v. It reports false if there
+// is no value, it is older than maxAge, or it can't be read.
+func (s *Store) Get(key string, maxAge time.Duration, v any) bool {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil || e.Key != key {
+		return false
+	}
+	if s.now().Sub(e.StoredAt) > maxAge {
+		return false
+	}
+	return json.Unmarshal(e.Value, v) == nil
+}
+
+// Put stores v under key
+func (s *Store) Put(key string, v any) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+	data, err := json.Marshal(entry{Key: key, StoredAt: s.now(), Value: value})
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Write then rename so a concurrent Get never sees half a file
+	tmp, err := os.CreateTemp(s.Dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the value stored under key, if any
+func (s *Store) Delete(key string) {
+	_ = os.Remove(s.path(key))
+}
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := &Store{Dir: t.TempDir(), Now: func() time.Time { return now }}
+
+	var got string
+	if s.Get("app/o/r", time.Minute, &got) {
+		t.Fatal("Get() on an empty store reported a hit")
+	}
+
+	if err := s.Put("app/o/r", "hello"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if !s.Get("app/o/r", time.Minute, &got) || got != "hello" {
+		t.Fatalf("Get() = %q; want hello", got)
+	}
+	if s.Get("app/o/other", time.Minute, &got) {
+		t.Error("Get() returned a value stored under another key")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if s.Get("app/o/r", time.Minute, &got) {
+		t.Error("Get() returned a value older than maxAge")
+	}
+	if !s.Get("app/o/r", time.Hour, &got) {
+		t.Error("Get() missed a value within maxAge")
+	}
+
+	s.Delete("app/o/r")
+	if s.Get("app/o/r", time.Hour, &got) {
+		t.Error("Get() returned a deleted value")
+	}
+}
@@ -2,6 +2,7 @@ package claude
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
@@ -39,6 +40,8 @@ type SessionOptions struct {
 }
 
 // RunWithPTY starts the command under a PTY, proxies IO, and supports detach.
+// It returns ErrDetached if the user detached, and otherwise the error from
+// waiting for the command, so an *exec.ExitError carries its exit status.
 func RunWithPTY(cmd *exec.Cmd, opts SessionOptions) error {
 	if cmd == nil {
 		return errors.New("nil command")
@@ -70,6 +73,17 @@ func RunWithPTY(cmd *exec.Cmd, opts SessionOptions) error {
 		interruptTimeout = DefaultInterruptTimeout
 	}
 
+	// Without a terminal on stdin there's nothing to proxy keystrokes from
+	if f, ok := ioCfg.Stdin.(*os.File); ok && !term.IsTerminal(int(f.Fd())) {
+		return runInherited(cmd, ioCfg, "stdin is not a terminal")
+	}
+
+	// Some containers and CI runners have no PTY devices. Probe first so a
+	// failure to start the command itself isn't mistaken for one.
+	if !ptyAvailable() {
+		return runInherited(cmd, ioCfg, "no PTY available")
+	}
+
 	ptmx, err := pty.Start(cmd)
 	if err != nil {
 		return err
@@ -163,6 +177,27 @@ func RunWithPTY(cmd *exec.Cmd, opts SessionOptions) error {
 	}
 }
 
+// ptyAvailable reports whether a PTY can be allocated
+func ptyAvailable() bool {
+	ptmx, tty, err := pty.Open()
+	if err != nil {
+		return false
+	}
+	_ = tty.Close()
+	_ = ptmx.Close()
+	return true
+}
+
+// runInherited runs cmd attached directly to the session IO, without the
+// PTY that detaching needs
+func runInherited(cmd *exec.Cmd, ioCfg SessionIO, reason string) error {
+	fmt.Fprintf(ioCfg.Stderr, "Warning: %s; running without detach support\n", reason)
+	cmd.Stdin = ioCfg.Stdin
+	cmd.Stdout = ioCfg.Stdout
+	cmd.Stderr = ioCfg.Stderr
+	return cmd.Run()
+}
+
 func detach(cmd *exec.Cmd, waitErr <-chan error, outputDone <-chan struct{}, delay, timeout time.Duration) error {
 	sendInterrupts(cmd.Process, delay)
 
@@ -2,17 +2,73 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"sync"
+	"time"
 )
 
 const (
 	DefaultAPIUrl = "https://kiosk.app"
 	EnvAPIUrl     = "KIOSK_API_URL"
+	EnvAppsDir    = "KIOSK_APPS_DIR"
+	EnvConfig     = "KIOSK_CONFIG"
+	EnvRegistry   = "KIOSK_REGISTRY"
+	EnvClaudePath = "KIOSK_CLAUDE_PATH"
+	EnvDebug      = "KIOSK_DEBUG"
+
+	EnvUpdateBaseURL = "KIOSK_UPDATE_BASE_URL"
 )
 
 // Config holds the kiosk CLI configuration
 type Config struct {
-	APIUrl string `json:"apiUrl"`
+	APIUrl     string          `json:"apiUrl"`
+	Registry   string          `json:"registry,omitempty"`   // app metadata mirror: an API URL, or a file:// directory of app JSON
+	AppsDir    string          `json:"appsDir,omitempty"`    // overrides ~/.kiosk/apps
+	Editor     string          `json:"editor,omitempty"`     // used when $VISUAL and $EDITOR are unset
+	ClaudePath string          `json:"claudePath,omitempty"` // claude binary to run instead of the one in PATH; $KIOSK_CLAUDE_PATH wins
+	Telemetry  TelemetryConfig `json:"telemetry"`
+	Browse     BrowseConfig    `json:"browse"`
+	Git        GitConfig       `json:"git"`
+	Updates    UpdatesConfig   `json:"updates"`
+	UI         UIConfig        `json:"ui"`
+
+	// KeyBindings remaps TUI actions to keys, e.g. {"back": ["esc", "h"]}
+	KeyBindings map[string][]string `json:"keybindings,omitempty"`
+}
+
+// UIConfig controls how the TUI looks
+type UIConfig struct {
+	Spinner     string `json:"spinner,omitempty"`     // spinner style name, e.g. "line" for ASCII-only terminals; unset means "dot"
+	IdleTimeout string `json:"idleTimeout,omitempty"` // duration, e.g. "10m", after which an idle TUI goes home or quits; unset disables it
+}
+
+// UpdatesConfig controls the check for new kiosk releases
+type UpdatesConfig struct {
+	DisableCheck  bool      `json:"disableCheck,omitempty"`
+	LastChecked   time.Time `json:"lastChecked,omitempty"`
+	LatestVersion string    `json:"latestVersion,omitempty"` // newest release seen by the last check
+
+	// BaseURL points self-updates at a mirror of the GitHub releases,
+	// e.g. behind a firewall that blocks github.com
+	BaseURL string `json:"baseUrl,omitempty"`
+}
+
+// GitConfig controls the git commands kiosk runs
+type GitConfig struct {
+	Timeout    string `json:"timeout,omitempty"`    // per-command limit as a duration, e.g. "10m"
+	CloneDepth *int   `json:"cloneDepth,omitempty"` // commits of history to clone; 0 for all, unset for 1
+}
+
+// BrowseConfig controls the TUI's browse view
+type BrowseConfig struct {
+	PageSize int `json:"pageSize,omitempty"` // apps fetched per page; 0 sizes pages to the screen
+}
+
+// TelemetryConfig controls local activity logging.
+// Nothing here is ever transmitted; it only affects files under ~/.kiosk.
+type TelemetryConfig struct {
+	LocalLog bool `json:"localLog"` // append install/run/update/remove events to events.jsonl
 }
 
 // Default returns a Config with default values
@@ -22,11 +78,39 @@ func Default() *Config {
 	}
 }
 
+// registryOverride is the registry set with SetRegistry
+var registryOverride string
+
+// SetRegistry overrides the configured registry, as with --registry. It
+// takes precedence over $KIOSK_REGISTRY.
+func SetRegistry(registry string) {
+	registryOverride = registry
+}
+
 // Load reads the config from disk and applies env var overrides
 func Load() (*Config, error) {
+	cfg, err := loadFile()
+	if err != nil {
+		return nil, err
+	}
+
+	// Env var overrides
+	if envURL := os.Getenv(EnvAPIUrl); envURL != "" {
+		cfg.APIUrl = envURL
+	}
+	if registry := registryOverride; registry != "" {
+		cfg.Registry = registry
+	} else if registry := os.Getenv(EnvRegistry); registry != "" {
+		cfg.Registry = registry
+	}
+
+	return cfg, nil
+}
+
+// loadFile reads the config from disk as saved, without overrides
+func loadFile() (*Config, error) {
 	cfg := Default()
 
-	// Try to read from config file
 	data, err := os.ReadFile(ConfigPath())
 	if err == nil {
 		if err := json.Unmarshal(data, cfg); err != nil {
@@ -35,13 +119,19 @@ func Load() (*Config, error) {
 	} else if !os.IsNotExist(err) {
 		return nil, err
 	}
+	return cfg, nil
+}
 
-	// Env var overrides
-	if envURL := os.Getenv(EnvAPIUrl); envURL != "" {
-		cfg.APIUrl = envURL
+// Update applies fn to the config as saved on disk and writes it back. Env
+// var and --registry overrides are left out, so state kiosk records for
+// itself never persists a one-off override as a setting.
+func Update(fn func(*Config)) error {
+	cfg, err := loadFile()
+	if err != nil {
+		return err
 	}
-
-	return cfg, nil
+	fn(cfg)
+	return Save(cfg)
 }
 
 // Save writes the config to disk
@@ -56,14 +146,30 @@ func Save(cfg *Config) error {
 		return err
 	}
 
+	defer forgetAppsDirSetting()
 	return os.WriteFile(ConfigPath(), data, 0644)
 }
 
+// IsFreshInstall reports whether kiosk has never been used here: the kiosk
+// directory is missing or empty, so there is no config, credentials or apps
+func IsFreshInstall() bool {
+	entries, err := os.ReadDir(KioskDir())
+	if os.IsNotExist(err) {
+		return true
+	}
+	return err == nil && len(entries) == 0
+}
+
+// writableAppsDirs are the apps directories EnsureInitialized has found
+// writable, so each is only probed once per process
+var writableAppsDirs sync.Map
+
 // EnsureInitialized creates the kiosk directory structure if it doesn't exist
 func EnsureInitialized() error {
+	appsDir := AppsDir()
 	dirs := []string{
 		KioskDir(),
-		AppsDir(),
+		appsDir,
 	}
 
 	for _, dir := range dirs {
@@ -72,6 +178,18 @@ func EnsureInitialized() error {
 		}
 	}
 
+	// A relocated apps dir may be on a read-only or unmounted disk; catch
+	// that here rather than partway through a clone
+	if _, ok := writableAppsDirs.Load(appsDir); !ok {
+		probe, err := os.CreateTemp(appsDir, ".write-test-*")
+		if err != nil {
+			return fmt.Errorf("apps directory %s is not writable: %w", appsDir, err)
+		}
+		probe.Close()
+		os.Remove(probe.Name())
+		writableAppsDirs.Store(appsDir, true)
+	}
+
 	// Create default config if it doesn't exist
 	if _, err := os.Stat(ConfigPath()); os.IsNotExist(err) {
 		data, err := json.MarshalIndent(Default(), "", "  ")
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// recoveryWarnings describe corrupt files set aside since the last call to
+// TakeWarnings
+var (
+	recoveryMu       sync.Mutex
+	recoveryWarnings []string
+)
+
+// RecoverCorruptFile moves a file that failed to parse aside as
+// <path>.corrupt-<timestamp>, so callers can carry on as if the file didn't
+// exist. The backup is kept for inspection. A warning describing what
+// happened is returned by the next TakeWarnings, for the command to show
+// when the terminal is its own again. If the file can't be moved, an error
+// is returned instead and callers must not carry on, since saving would
+// overwrite the only copy.
+func RecoverCorruptFile(path string, cause error) error {
+	backup := fmt.Sprintf("%s.corrupt-%s", path, time.Now().Format("20060102-150405"))
+	if err := os.Rename(path, backup); err != nil {
+		return fmt.Errorf("%s is corrupt (%v) and could not be moved aside: %w", path, cause, err)
+	}
+
+	recoveryMu.Lock()
+	defer recoveryMu.Unlock()
+	recoveryWarnings = append(recoveryWarnings,
+		fmt.Sprintf("%s was corrupt (%v); moved it to %s and continuing without it", path, cause, backup))
+	return nil
+}
+
+// TakeWarnings returns the warnings from RecoverCorruptFile since the last
+// call and clears them
+func TakeWarnings() []string {
+	recoveryMu.Lock()
+	defer recoveryMu.Unlock()
+	warnings := recoveryWarnings
+	recoveryWarnings = nil
+	return warnings
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 )
 
 const (
@@ -10,10 +12,38 @@ const (
 	appsDirName    = "apps"
 	configFileName = "config.json"
 	sessionsFile   = "sessions.json"
+	eventsFile     = "events.jsonl"
+	cacheDirName   = "cache"
 )
 
-// KioskDir returns the path to ~/.kiosk
+// configOverride is the config file set with SetConfigPath
+var configOverride string
+
+// SetConfigPath points kiosk at an alternate config file, as with --config.
+// It takes precedence over $KIOSK_CONFIG.
+func SetConfigPath(path string) {
+	configOverride = path
+}
+
+// configFileOverride returns the alternate config file set with
+// SetConfigPath or $KIOSK_CONFIG, or "" if there is none
+func configFileOverride() string {
+	if configOverride != "" {
+		return expandHome(configOverride)
+	}
+	if path := os.Getenv(EnvConfig); path != "" {
+		return expandHome(path)
+	}
+	return ""
+}
+
+// KioskDir returns the path to ~/.kiosk. When an alternate config file is
+// in use, its directory holds the index, credentials and other state instead.
 func KioskDir() string {
+	if path := configFileOverride(); path != "" {
+		return filepath.Dir(path)
+	}
+
 	home, err := os.UserHomeDir()
 	if err != nil {
 		// Fallback to current directory if home can't be determined
@@ -22,18 +52,90 @@ func KioskDir() string {
 	return filepath.Join(home, kioskDirName)
 }
 
-// AppsDir returns the path to ~/.kiosk/apps
+// AppsDir returns the directory apps are installed into. It defaults to
+// ~/.kiosk/apps and can be moved with $KIOSK_APPS_DIR or the appsDir config
+// setting, in that order of precedence.
 func AppsDir() string {
+	if dir := os.Getenv(EnvAppsDir); dir != "" {
+		return expandHome(dir)
+	}
+	if dir := configuredAppsDir(); dir != "" {
+		return expandHome(dir)
+	}
+	return DefaultAppsDir()
+}
+
+// appsDirSetting caches the appsDir setting by config file, so AppPath
+// doesn't parse the config once per app. Save clears it.
+var appsDirSetting struct {
+	sync.Mutex
+	configPath string // "" until the setting has been read
+	value      string
+}
+
+// configuredAppsDir returns the appsDir setting from the config file, or ""
+func configuredAppsDir() string {
+	path := ConfigPath()
+	appsDirSetting.Lock()
+	defer appsDirSetting.Unlock()
+	if appsDirSetting.configPath != path {
+		appsDirSetting.value = ""
+		if cfg, err := loadFile(); err == nil {
+			appsDirSetting.value = cfg.AppsDir
+		}
+		appsDirSetting.configPath = path
+	}
+	return appsDirSetting.value
+}
+
+// forgetAppsDirSetting makes the next AppsDir read the config file again
+func forgetAppsDirSetting() {
+	appsDirSetting.Lock()
+	defer appsDirSetting.Unlock()
+	appsDirSetting.configPath = ""
+}
+
+// DefaultAppsDir returns the path to ~/.kiosk/apps
+func DefaultAppsDir() string {
 	return filepath.Join(KioskDir(), appsDirName)
 }
 
-// AppPath returns the path to a specific app: ~/.kiosk/apps/org/repo
+// AppPath returns the path to a specific app: <apps dir>/org/repo.
+// Apps installed under the default directory before the apps directory was
+// moved are still found there until they are migrated.
 func AppPath(org, repo string) string {
-	return filepath.Join(AppsDir(), org, repo)
+	path := filepath.Join(AppsDir(), org, repo)
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+
+	legacy := filepath.Join(DefaultAppsDir(), org, repo)
+	if legacy != path {
+		if _, err := os.Stat(legacy); err == nil {
+			return legacy
+		}
+	}
+	return path
 }
 
-// ConfigPath returns the path to ~/.kiosk/config.json
+// expandHome replaces a leading ~ with the user's home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// ConfigPath returns the path to ~/.kiosk/config.json, or the alternate
+// config file if one is set
 func ConfigPath() string {
+	if path := configFileOverride(); path != "" {
+		return path
+	}
 	return filepath.Join(KioskDir(), configFileName)
 }
 
@@ -41,3 +143,13 @@ func ConfigPath() string {
 func SessionsPath() string {
 	return filepath.Join(KioskDir(), sessionsFile)
 }
+
+// EventsPath returns the path to ~/.kiosk/events.jsonl
+func EventsPath() string {
+	return filepath.Join(KioskDir(), eventsFile)
+}
+
+// CacheDir returns the path to ~/.kiosk/cache
+func CacheDir() string {
+	return filepath.Join(KioskDir(), cacheDirName)
+}
@@ -0,0 +1,175 @@
+// Package dotenv parses .env files of KEY=value lines.
+//
+// Blank lines and lines starting with # are ignored, and a leading "export "
+// is allowed. Unquoted values are trimmed and end at a " #" comment. Single-
+// quoted values are taken literally. Double-quoted values may span lines and
+// understand \n, \r, \t, \" and \\ escapes.
+//
+// Errors never include values, since .env files usually hold secrets.
+package dotenv
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Var is a single variable from a dotenv file
+type Var struct {
+	Key   string
+	Value string
+}
+
+var keyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// Load reads and parses the dotenv file at path
+func Load(path string) ([]Var, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+	vars, err := Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return vars, nil
+}
+
+// Parse parses dotenv content. Variables are returned in file order; a key
+// that appears more than once keeps its last value.
+func Parse(content string) ([]Var, error) {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	var vars []Var
+	index := map[string]int{}
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, rest, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=value", lineNo)
+		}
+		if !keyPattern.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", lineNo, key)
+		}
+
+		rest = strings.TrimLeft(rest, " \t")
+		var value string
+		switch {
+		case strings.HasPrefix(rest, `"`):
+			// Keep reading lines until the closing quote
+			text := rest[1:]
+			for {
+				v, tail, closed := parseDoubleQuoted(text)
+				if closed {
+					if !isComment(tail) {
+						return nil, fmt.Errorf("line %d: unexpected text after quoted value for %s", lineNo, key)
+					}
+					value = v
+					break
+				}
+				if i+1 >= len(lines) {
+					return nil, fmt.Errorf("line %d: unterminated quoted value for %s", lineNo, key)
+				}
+				i++
+				text += "\n" + lines[i]
+			}
+
+		case strings.HasPrefix(rest, "'"):
+			end := strings.Index(rest[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated quoted value for %s", lineNo, key)
+			}
+			if !isComment(rest[end+2:]) {
+				return nil, fmt.Errorf("line %d: unexpected text after quoted value for %s", lineNo, key)
+			}
+			value = rest[1 : end+1]
+
+		default:
+			value = rest
+			if strings.HasPrefix(value, "#") {
+				value = ""
+			} else if j := strings.Index(value, " #"); j >= 0 {
+				value = value[:j]
+			} else if j := strings.Index(value, "\t#"); j >= 0 {
+				value = value[:j]
+			}
+			value = strings.TrimSpace(value)
+		}
+
+		if j, seen := index[key]; seen {
+			vars[j].Value = value
+			continue
+		}
+		index[key] = len(vars)
+		vars = append(vars, Var{Key: key, Value: value})
+	}
+	return vars, nil
+}
+
+// parseDoubleQuoted decodes s up to its first unescaped double quote,
+// returning the decoded value and the text after the quote. closed is false
+// if s has no closing quote.
+func parseDoubleQuoted(s string) (value, tail string, closed bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"':
+			return b.String(), s[i+1:], true
+		case c == '\\' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\':
+				b.WriteByte(s[i])
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", "", false
+}
+
+// isComment reports whether s, the text after a quoted value, is empty or
+// only a comment
+func isComment(s string) bool {
+	s = strings.TrimSpace(s)
+	return s == "" || strings.HasPrefix(s, "#")
+}
+
+// Merge returns environ with vars applied, replacing any existing entries
+// for the same keys. environ is in os.Environ form.
+func Merge(environ []string, vars []Var) []string {
+	override := make(map[string]bool, len(vars))
+	for _, v := range vars {
+		override[v.Key] = true
+	}
+
+	merged := make([]string, 0, len(environ)+len(vars))
+	for _, kv := range environ {
+		key, _, _ := strings.Cut(kv, "=")
+		if !override[key] {
+			merged = append(merged, kv)
+		}
+	}
+	for _, v := range vars {
+		merged = append(merged, v.Key+"="+v.Value)
+	}
+	return merged
+}
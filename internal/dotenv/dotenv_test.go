@@ -0,0 +1,96 @@
+package dotenv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Var
+		wantErr bool
+	}{
+		{
+			name:    "plain values and comments",
+			content: "# comment\n\nFOO=bar\nexport BAZ = qux  # trailing\nEMPTY=\nHASH=a#b\n",
+			want: []Var{
+				{Key: "FOO", Value: "bar"},
+				{Key: "BAZ", Value: "qux"},
+				{Key: "EMPTY", Value: ""},
+				{Key: "HASH", Value: "a#b"},
+			},
+		},
+		{
+			name:    "double quotes",
+			content: `A="hello # not a comment" # comment` + "\n" + `B="line\nbreak \"quoted\" \\ done"`,
+			want: []Var{
+				{Key: "A", Value: "hello # not a comment"},
+				{Key: "B", Value: "line\nbreak \"quoted\" \\ done"},
+			},
+		},
+		{
+			name:    "multiline double quotes",
+			content: "KEY=\"-----BEGIN-----\nabc\n-----END-----\"\nNEXT=1",
+			want: []Var{
+				{Key: "KEY", Value: "-----BEGIN-----\nabc\n-----END-----"},
+				{Key: "NEXT", Value: "1"},
+			},
+		},
+		{
+			name:    "single quotes are literal",
+			content: `A='$HOME \n "x"'`,
+			want:    []Var{{Key: "A", Value: `$HOME \n "x"`}},
+		},
+		{
+			name:    "later value wins",
+			content: "A=1\r\nB=2\r\nA=3\r\n",
+			want:    []Var{{Key: "A", Value: "3"}, {Key: "B", Value: "2"}},
+		},
+		{
+			name:    "missing equals",
+			content: "FOO\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid key",
+			content: "1FOO=bar\n",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated quote",
+			content: "SECRET=\"hunter2\n",
+			wantErr: true,
+		},
+		{
+			name:    "text after quote",
+			content: "SECRET='hunter2' extra\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && strings.Contains(err.Error(), "hunter2") {
+				t.Errorf("Parse() error leaks the value: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	got := Merge([]string{"PATH=/bin", "FOO=old", "HOME=/root"}, []Var{{Key: "FOO", Value: "new"}, {Key: "BAR", Value: "x=y"}})
+	want := []string{"PATH=/bin", "HOME=/root", "FOO=new", "BAR=x=y"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() = %v, want %v", got, want)
+	}
+}
@@ -35,9 +35,15 @@ func FormatError(err error) string {
 		formatAuthError(&sb, authErr)
 	} else if netErr, ok := IsNetworkError(err); ok {
 		formatNetworkError(&sb, netErr)
+	} else if depErr, ok := IsDependencyError(err); ok {
+		formatDependencyError(&sb, depErr)
 	} else {
 		formatGenericError(&sb, err)
 	}
+	if hintErr, ok := IsHintError(err); ok {
+		sb.WriteString(hintErr.Hint)
+		sb.WriteString("\n")
+	}
 
 	return sb.String()
 }
@@ -85,6 +91,18 @@ func formatNetworkError(sb *strings.Builder, err *NetworkError) {
 	}
 }
 
+func formatDependencyError(sb *strings.Builder, err *DependencyError) {
+	sb.WriteString(color(style.Red+style.Bold, "Error: "))
+	sb.WriteString(err.Message)
+	sb.WriteString("\n")
+
+	if err.Suggestion != "" {
+		sb.WriteString("\n")
+		sb.WriteString(err.Suggestion)
+		sb.WriteString("\n")
+	}
+}
+
 func formatGenericError(sb *strings.Builder, err error) {
 	sb.WriteString(color(style.Red+style.Bold, "Error: "))
 	sb.WriteString(getGenericNarrativeMessage(err))
@@ -169,6 +187,8 @@ func getNetworkNarrativeMessage(err *NetworkError) string {
 		return "The request to the Kiosk API timed out. Please check your internet connection and try again."
 	case strings.Contains(msg, "ssl") || strings.Contains(msg, "tls") || strings.Contains(msg, "certificate"):
 		return "There was a security certificate error connecting to the Kiosk API. Please check your network configuration."
+	case strings.Contains(msg, "unavailable"):
+		return "The Kiosk API is currently unavailable. Please try again in a few minutes."
 	default:
 		return "Unable to connect to the Kiosk API. Please check your internet connection and try again."
 	}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os/exec"
 )
 
 // APIError represents an error returned by the Kiosk API.
@@ -151,6 +152,88 @@ func NewNetworkError(message string, cause error) *NetworkError {
 	}
 }
 
+// ExitMissingDependency is the exit code used when a required external
+// program is not installed, matching the shell's "command not found".
+const ExitMissingDependency = 127
+
+// DependencyError represents a required external program that is not installed.
+type DependencyError struct {
+	Name       string // Program name, e.g. "claude"
+	Message    string
+	Suggestion string // How to install it
+}
+
+func (e *DependencyError) Error() string {
+	return e.Message
+}
+
+// NewDependencyError creates a new missing dependency error.
+func NewDependencyError(name, suggestion string) *DependencyError {
+	return &DependencyError{
+		Name:       name,
+		Message:    fmt.Sprintf("%s is not installed or not in your PATH", name),
+		Suggestion: suggestion,
+	}
+}
+
+// ExitError reports that a program kiosk ran in the foreground, such as
+// Claude, exited with a non-zero status. kiosk exits with the same code.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// NewExitError wraps err in an ExitError if it comes from the program
+// exiting with a status, and returns it unchanged otherwise, e.g. when the
+// program couldn't start or was killed by a signal.
+func NewExitError(err error) error {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() < 0 {
+		return err
+	}
+	return &ExitError{Code: exitErr.ExitCode(), Err: err}
+}
+
+// HintError adds a hint to an error, such as close matches for a mistyped
+// app name. The wrapped error is still found by IsAPIError and the other
+// checks, and is displayed as usual with the hint after it.
+type HintError struct {
+	Err  error
+	Hint string
+}
+
+func (e *HintError) Error() string {
+	return e.Err.Error() + "; " + e.Hint
+}
+
+func (e *HintError) Unwrap() error {
+	return e.Err
+}
+
+// WithHint wraps err in a HintError.
+func WithHint(err error, hint string) error {
+	return &HintError{Err: err, Hint: hint}
+}
+
+// ExitCode returns the process exit code to use for err.
+func ExitCode(err error) int {
+	if _, ok := IsDependencyError(err); ok {
+		return ExitMissingDependency
+	}
+	if exitErr, ok := IsExitError(err); ok {
+		return exitErr.Code
+	}
+	return 1
+}
+
 // Helper functions for checking error types
 
 // IsAPIError checks if the error is an APIError and returns it.
@@ -179,3 +262,30 @@ func IsNetworkError(err error) (*NetworkError, bool) {
 	}
 	return nil, false
 }
+
+// IsDependencyError checks if the error is a DependencyError and returns it.
+func IsDependencyError(err error) (*DependencyError, bool) {
+	var depErr *DependencyError
+	if errors.As(err, &depErr) {
+		return depErr, true
+	}
+	return nil, false
+}
+
+// IsHintError checks if the error is a HintError and returns it.
+func IsHintError(err error) (*HintError, bool) {
+	var hintErr *HintError
+	if errors.As(err, &hintErr) {
+		return hintErr, true
+	}
+	return nil, false
+}
+
+// IsExitError checks if the error is an ExitError and returns it.
+func IsExitError(err error) (*ExitError, bool) {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr, true
+	}
+	return nil, false
+}
@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestNewExitError(t *testing.T) {
+	runErr := exec.Command("sh", "-c", "exit 3").Run()
+	err := NewExitError(fmt.Errorf("claude failed: %w", runErr))
+	if got := ExitCode(err); got != 3 {
+		t.Errorf("ExitCode() = %d; want 3", got)
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Error("NewExitError hid the *exec.ExitError")
+	}
+
+	other := errors.New("not started")
+	if got := NewExitError(other); got != other {
+		t.Errorf("NewExitError(%v) = %v; want it unchanged", other, got)
+	}
+	if NewExitError(nil) != nil {
+		t.Error("NewExitError(nil) != nil")
+	}
+}
+
+func TestWithHint(t *testing.T) {
+	err := WithHint(&APIError{StatusCode: 404, Message: "app not found"}, "Did you mean acme/weather?")
+
+	apiErr, ok := IsAPIError(err)
+	if !ok || !apiErr.IsNotFound() {
+		t.Fatalf("IsAPIError(%v) = %v, %v; want the 404", err, apiErr, ok)
+	}
+	if got := FormatError(err); !strings.Contains(got, "could not be found") || !strings.Contains(got, "Did you mean acme/weather?") {
+		t.Errorf("FormatError() = %q; want the not-found message and the hint", got)
+	}
+}
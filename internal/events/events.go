@@ -0,0 +1,101 @@
+// Package events maintains an opt-in, local-only log of app activity.
+// Events are appended to ~/.kiosk/events.jsonl when telemetry.localLog is
+// enabled in the config. Nothing in this package sends data anywhere.
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+)
+
+// Type identifies the kind of activity recorded.
+type Type string
+
+const (
+	Install Type = "install"
+	Run     Type = "run"
+	Update  Type = "update"
+	Remove  Type = "remove"
+)
+
+// Event is a single line in the event log.
+type Event struct {
+	Time   time.Time `json:"time"`
+	Type   Type      `json:"type"`
+	AppKey string    `json:"appKey"`
+}
+
+// Enabled reports whether local event logging is turned on in the config.
+func Enabled() bool {
+	cfg, err := config.Load()
+	if err != nil {
+		return false
+	}
+	return cfg.Telemetry.LocalLog
+}
+
+// Record appends an event to the log if logging is enabled.
+// It is a no-op when telemetry.localLog is off.
+func Record(t Type, appKey string) error {
+	if !Enabled() {
+		return nil
+	}
+
+	if err := os.MkdirAll(config.KioskDir(), 0755); err != nil {
+		return fmt.Errorf("create kiosk dir: %w", err)
+	}
+
+	data, err := json.Marshal(Event{
+		Time:   time.Now(),
+		Type:   t,
+		AppKey: appKey,
+	})
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+
+	f, err := os.OpenFile(config.EventsPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open events: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write events: %w", err)
+	}
+	return nil
+}
+
+// Load reads all events from the log in the order they were written.
+// Malformed lines are skipped. A missing log returns no events.
+func Load() ([]Event, error) {
+	f, err := os.Open(config.EventsPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read events: %w", err)
+	}
+	defer f.Close()
+
+	var result []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		result = append(result, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read events: %w", err)
+	}
+
+	return result, nil
+}
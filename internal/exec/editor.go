@@ -0,0 +1,110 @@
+package exec
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	kioskerrors "github.com/reflective-technologies/kiosk-cli/internal/errors"
+)
+
+// ErrNoEditor is returned when no editor could be resolved.
+var ErrNoEditor = errors.New("no editor found: set $VISUAL or $EDITOR, or run 'kiosk config set editor <command>'")
+
+// guiEditors are editors that open their own window and return immediately.
+// Everything else is assumed to take over the terminal.
+var guiEditors = map[string]bool{
+	"code":          true,
+	"code-insiders": true,
+	"codium":        true,
+	"cursor":        true,
+	"zed":           true,
+	"subl":          true,
+	"mate":          true,
+	"atom":          true,
+	"idea":          true,
+	"goland":        true,
+	"gedit":         true,
+	"kate":          true,
+	"notepad":       true,
+}
+
+// endOfOptionsEditors accept "--" to end option parsing before the path.
+// Others, e.g. micro and kak, treat it differently, so it's left off.
+var endOfOptionsEditors = map[string]bool{
+	"vi":    true,
+	"vim":   true,
+	"nvim":  true,
+	"nano":  true,
+	"emacs": true,
+}
+
+// defaultEditors returns per-OS fallbacks, tried in order.
+func defaultEditors() []string {
+	switch runtime.GOOS {
+	case "windows":
+		return []string{"code", "notepad"}
+	default:
+		return []string{"code", "vim", "vi", "nano"}
+	}
+}
+
+// EditorCmd builds an exec.Cmd that opens dir in the user's editor.
+// The editor is resolved from preferred (e.g. an --editor flag), $VISUAL,
+// $EDITOR, the configured editor, and finally per-OS defaults found in PATH.
+// terminal reports whether the editor needs the TTY and should be waited on;
+// GUI editors can be started and left running.
+func EditorCmd(dir, preferred, configured string) (cmd *exec.Cmd, terminal bool, err error) {
+	editor := resolveEditor(preferred, configured)
+	if editor == "" {
+		return nil, false, ErrNoEditor
+	}
+
+	// Editor values may carry arguments, e.g. "code --wait" or "emacs -nw"
+	fields := strings.Fields(editor)
+	name := fields[0]
+	if _, err := exec.LookPath(name); err != nil {
+		return nil, false, kioskerrors.NewDependencyError(name, "Choose a different editor with --editor or 'kiosk config set editor <command>'.")
+	}
+
+	base := strings.TrimSuffix(filepath.Base(name), ".exe")
+	terminal = !guiEditors[base]
+
+	args := append([]string{}, fields[1:]...)
+	if endOfOptionsEditors[base] {
+		args = append(args, "--")
+	}
+	args = append(args, dir)
+
+	cmd = exec.Command(name, args...)
+	cmd.Dir = dir
+	if terminal {
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	}
+	return cmd, terminal, nil
+}
+
+func resolveEditor(preferred, configured string) string {
+	for _, candidate := range []string{
+		preferred,
+		os.Getenv("VISUAL"),
+		os.Getenv("EDITOR"),
+		configured,
+	} {
+		if strings.TrimSpace(candidate) != "" {
+			return strings.TrimSpace(candidate)
+		}
+	}
+
+	for _, name := range defaultEditors() {
+		if _, err := exec.LookPath(name); err == nil {
+			return name
+		}
+	}
+	return ""
+}
@@ -1,12 +1,24 @@
 package exec
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"os/exec"
+	"runtime"
+	"strings"
+	"text/template"
+	"time"
 )
 
-// AuditPrompt is the prompt used for security audits before publishing.
-const AuditPrompt = `You are auditing this application for security issues before it is published to a public repository.
+// shellProbeTimeout bounds looking claude up through the user's interactive
+// shell, whose rc files may prompt or hang
+const shellProbeTimeout = 5 * time.Second
+
+// auditPromptTemplate is the prompt used for security audits before publishing.
+// Exclude lists ignore patterns the scan should skip and Include the negated
+// ones it should still cover; see AuditPrompt.
+var auditPromptTemplate = template.Must(template.New("audit").Parse(`You are auditing this application for security issues before it is published to a public repository.
 
 Perform the following checks:
 
@@ -25,8 +37,22 @@ Perform the following checks:
 3. **Configuration review**: Check for:
    - Proper .gitignore entries for sensitive files
    - Any configuration files that might contain secrets
+{{if .Exclude}}
+**Scope**: Skip files matching these patterns (gitignore syntax) in the codebase scan; they are dependencies, build output, or otherwise not published. Still report any ignored file that is tracked by git and looks sensitive.
+{{range .Exclude}}   - {{.}}
+{{end}}{{if .Include}}Still scan files matching these patterns, which the ignore files re-include with "!":
+{{range .Include}}   - {{.}}
+{{end}}{{end}}{{end}}
+{{if .JSON}}Report your findings as a single JSON object and nothing else, in this shape:
+
+{"findings": [{"file": "path/relative/to/repo", "line": 12, "severity": "critical", "type": "secret", "recommendation": "Remove the key and rotate it"}]}
+
+- "severity" is one of "critical", "warning" or "info"
+- "type" is a short category such as "secret", "personal-info", "credentials-in-url", "private-key", "env-file", "git-history" or "gitignore"
+- "line" is the 1-based line number, or 0 if the finding isn't tied to a line; for git history findings put the commit hash in "recommendation"
+- If no issues are found, output {"findings": []}
 
-Report your findings clearly, listing:
+IMPORTANT: Output ONLY the JSON object. No markdown, no preamble, no explanations.{{else}}Report your findings clearly, listing:
 - Any issues found with file paths and line numbers
 - Severity (critical/warning/info)
 - Recommended remediation steps
@@ -35,11 +61,99 @@ If no issues are found, confirm the repository appears safe for publication.
 
 IMPORTANT: 
 - Output ONLY the markdown report. No preamble, no explanations, no follow-up questions—just the report itself.
-- Format your response as valid markdown with proper headers, lists, and code blocks where appropriate.`
+- Format your response as valid markdown with proper headers, lists, and code blocks where appropriate.{{end}}`))
+
+// AuditPrompt returns the security audit prompt for dir, scoped by the
+// patterns in its .kioskignore and .gitignore files.
+func AuditPrompt(dir string) string {
+	return auditPrompt(dir, false)
+}
+
+// AuditJSONPrompt is AuditPrompt asking for the findings as JSON instead of
+// a markdown report
+func AuditJSONPrompt(dir string) string {
+	return auditPrompt(dir, true)
+}
+
+func auditPrompt(dir string, asJSON bool) string {
+	exclude, include := AuditIgnorePatterns(dir)
+	var b strings.Builder
+	_ = auditPromptTemplate.Execute(&b, struct {
+		Exclude []string
+		Include []string
+		JSON    bool
+	}{
+		Exclude: exclude,
+		Include: include,
+		JSON:    asJSON,
+	})
+	return b.String()
+}
+
+// claudePath is the claude binary set with SetClaudePath
+var claudePath string
+
+// SetClaudePath makes ClaudeCmd run the claude binary at path instead of
+// looking for claude in PATH and the user's shell; "" restores the lookup.
+func SetClaudePath(path string) {
+	claudePath = path
+}
+
+// ClaudePath returns the claude binary set with SetClaudePath, or "".
+func ClaudePath() string {
+	return claudePath
+}
+
+// CheckClaudePath returns an error if path is not an executable file.
+func CheckClaudePath(path string) error {
+	if _, err := exec.LookPath(path); err != nil {
+		return fmt.Errorf("claude path %s is not an executable file: %w", path, err)
+	}
+	return nil
+}
+
+// ResolveClaude describes the claude ClaudeCmd runs: the binary set with
+// SetClaudePath, the one found in PATH, or "claude" in the user's shell.
+func ResolveClaude() string {
+	if claudePath != "" {
+		return claudePath
+	}
+	if path, err := exec.LookPath("claude"); err == nil {
+		return path
+	}
+	return "claude (from your shell)"
+}
+
+// ClaudeAvailable reports whether claude can be run: the binary set with
+// SetClaudePath, or claude from PATH or through the user's interactive shell
+// (e.g. an alias or shell function).
+func ClaudeAvailable() bool {
+	if claudePath != "" {
+		return CheckClaudePath(claudePath) == nil
+	}
+	if _, err := exec.LookPath("claude"); err == nil {
+		return true
+	}
+
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "sh"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shellProbeTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, shell, "-i", "-c", "command -v claude")
+	cmd.Stdin = nil // read /dev/null, never the terminal
+	cmd.WaitDelay = time.Second
+	return cmd.Run() == nil
+}
 
 // ClaudeCmd builds an exec.Cmd for running claude with the given args.
-// It falls back to running through the user's shell if claude is not in PATH.
+// It runs the binary set with SetClaudePath if there is one, and falls back
+// to running through the user's shell if claude is not in PATH.
 func ClaudeCmd(args ...string) *exec.Cmd {
+	if claudePath != "" {
+		return exec.Command(claudePath, args...)
+	}
 	if _, err := exec.LookPath("claude"); err == nil {
 		return exec.Command("claude", args...)
 	}
@@ -55,3 +169,19 @@ func ClaudeCmd(args ...string) *exec.Cmd {
 	shellArgs = append(shellArgs, args...)
 	return exec.Command(shell, shellArgs...)
 }
+
+// ShellCmd builds an exec.Cmd for an interactive session in the user's
+// shell: $SHELL, or the platform default if it's unset.
+func ShellCmd() *exec.Cmd {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "sh"
+		if runtime.GOOS == "windows" {
+			shell = "cmd.exe"
+			if comspec := os.Getenv("COMSPEC"); comspec != "" {
+				shell = comspec
+			}
+		}
+	}
+	return exec.Command(shell)
+}
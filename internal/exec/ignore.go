@@ -0,0 +1,75 @@
+package exec
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// KioskIgnoreFile lists paths the security audit should skip, in gitignore syntax
+const KioskIgnoreFile = ".kioskignore"
+
+// defaultAuditIgnores are always skipped; they hold third-party code, not the app's own
+var defaultAuditIgnores = []string{"node_modules/", "vendor/"}
+
+// AuditIgnorePatterns returns the ignore patterns for an audit of dir: the
+// defaults, then .gitignore, then .kioskignore, without duplicates. Missing
+// files are skipped. As in gitignore, a later !pattern re-includes what an
+// earlier pattern ignored: if it names the same pattern, that pattern is
+// dropped, and otherwise it is returned in include for the scan to keep.
+func AuditIgnorePatterns(dir string) (exclude, include []string) {
+	var patterns []string
+	patterns = append(patterns, defaultAuditIgnores...)
+	for _, name := range []string{".gitignore", KioskIgnoreFile} {
+		patterns = append(patterns, readIgnoreFile(filepath.Join(dir, name))...)
+	}
+
+	for _, p := range patterns {
+		if negated, ok := strings.CutPrefix(p, "!"); ok {
+			if i := indexPattern(exclude, negated); i >= 0 {
+				exclude = slices.Delete(exclude, i, i+1)
+			} else if indexPattern(include, negated) < 0 {
+				include = append(include, negated)
+			}
+			continue
+		}
+		if i := indexPattern(include, p); i >= 0 {
+			include = slices.Delete(include, i, i+1)
+		}
+		if indexPattern(exclude, p) < 0 {
+			exclude = append(exclude, p)
+		}
+	}
+	return exclude, include
+}
+
+// indexPattern returns the index of p in patterns, or -1. A directory may be
+// written with or without its trailing slash.
+func indexPattern(patterns []string, p string) int {
+	return slices.IndexFunc(patterns, func(existing string) bool {
+		return strings.TrimSuffix(existing, "/") == strings.TrimSuffix(p, "/")
+	})
+}
+
+// readIgnoreFile parses a gitignore-style file into its patterns,
+// dropping blank lines and comments
+func readIgnoreFile(path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns
+}
@@ -0,0 +1,94 @@
+package exec
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAuditIgnorePatterns(t *testing.T) {
+	tests := []struct {
+		name        string
+		gitignore   string
+		kioskignore string
+		exclude     []string
+		include     []string
+	}{
+		{
+			name:    "defaults only",
+			exclude: []string{"node_modules/", "vendor/"},
+		},
+		{
+			name:        "merged without duplicates",
+			gitignore:   "# build output\ndist/\n\n.env\n",
+			kioskignore: "dist/\nfixtures/\n",
+			exclude:     []string{"node_modules/", "vendor/", "dist/", ".env", "fixtures/"},
+		},
+		{
+			name:        "kioskignore negates a gitignore entry",
+			gitignore:   "dist/\n.env\n",
+			kioskignore: "!.env\n",
+			exclude:     []string{"node_modules/", "vendor/", "dist/"},
+		},
+		{
+			name:        "kioskignore negates a default",
+			kioskignore: "!vendor\n",
+			exclude:     []string{"node_modules/"},
+		},
+		{
+			name:      "negation inside a broader pattern is kept to scan",
+			gitignore: ".env*\n!.env.example\n",
+			exclude:   []string{"node_modules/", "vendor/", ".env*"},
+			include:   []string{".env.example"},
+		},
+		{
+			name:        "ignored again after a negation",
+			gitignore:   "!fixtures/secret.json\n",
+			kioskignore: "fixtures/secret.json\n",
+			exclude:     []string{"node_modules/", "vendor/", "fixtures/secret.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range map[string]string{".gitignore": tt.gitignore, KioskIgnoreFile: tt.kioskignore} {
+				if content == "" {
+					continue
+				}
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			exclude, include := AuditIgnorePatterns(dir)
+			if !reflect.DeepEqual(exclude, tt.exclude) {
+				t.Errorf("exclude = %q, want %q", exclude, tt.exclude)
+			}
+			if (len(include) > 0 || len(tt.include) > 0) && !reflect.DeepEqual(include, tt.include) {
+				t.Errorf("include = %q, want %q", include, tt.include)
+			}
+		})
+	}
+}
+
+func TestAuditPromptListsNegations(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, KioskIgnoreFile), []byte(".env*\n!.env.example\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	prompt := AuditPrompt(dir)
+	skip, scan, ok := strings.Cut(prompt, "Still scan")
+	if !ok {
+		t.Fatalf("prompt has no list of patterns to still scan:\n%s", prompt)
+	}
+	if strings.Contains(skip, ".env.example") || strings.Contains(skip, "!") {
+		t.Errorf("negated pattern listed as one to skip:\n%s", skip)
+	}
+	if !strings.Contains(scan, "- .env.example") {
+		t.Errorf("negated pattern not listed as one to scan:\n%s", scan)
+	}
+}
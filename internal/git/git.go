@@ -0,0 +1,310 @@
+// Package git runs the git commands kiosk needs to install and update apps.
+// Commands go through a Runner, so callers can be tested without git.
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/giturl"
+)
+
+// Runner runs git with args in dir, writing its output to stdout and
+// stderr
+type Runner interface {
+	Run(ctx context.Context, dir string, stdout, stderr io.Writer, args ...string) error
+}
+
+// ExecRunner runs the git binary. Git is told never to prompt, so a repo
+// that needs credentials fails right away instead of waiting on input that
+// will never come.
+type ExecRunner struct{}
+
+// Run implements Runner
+func (ExecRunner) Run(ctx context.Context, dir string, stdout, stderr io.Writer, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if os.Getenv("GIT_SSH_COMMAND") == "" {
+		cmd.Env = append(cmd.Env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes")
+	}
+	return cmd.Run()
+}
+
+// Git runs git commands, each limited to Timeout
+type Git struct {
+	Runner  Runner
+	Timeout time.Duration // 0 means no limit
+
+	// TimeoutHint is appended to timeout errors, e.g. to name the setting
+	// that raises the limit
+	TimeoutHint string
+}
+
+// New returns a Git that runs the git binary
+func New(timeout time.Duration) *Git {
+	return &Git{Runner: ExecRunner{}, Timeout: timeout}
+}
+
+// Available reports whether the git binary is on PATH
+func Available() bool {
+	_, err := exec.LookPath("git")
+	return err == nil
+}
+
+func (g *Git) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if g.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, g.Timeout)
+}
+
+// combined runs git in dir with the timeout, returning its combined output
+func (g *Git) combined(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+
+	var out bytes.Buffer
+	if err := g.Runner.Run(ctx, dir, &out, &out, args...); err != nil {
+		return out.Bytes(), g.error(ctx, args, out.Bytes(), err)
+	}
+	return out.Bytes(), nil
+}
+
+// error describes a failed git command run under ctx. It prefers git's own
+// output and explains timeouts and cancellation.
+func (g *Git) error(ctx context.Context, args []string, output []byte, err error) error {
+	name := strings.Join(args, " ")
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		if g.TimeoutHint != "" {
+			return fmt.Errorf("git %s timed out after %s (%s)", name, g.Timeout, g.TimeoutHint)
+		}
+		return fmt.Errorf("git %s timed out after %s", name, g.Timeout)
+	case errors.Is(ctx.Err(), context.Canceled):
+		return fmt.Errorf("git %s cancelled", name)
+	}
+	if out := strings.TrimSpace(string(output)); out != "" {
+		return fmt.Errorf("git %s failed: %s", name, out)
+	}
+	return fmt.Errorf("git %s failed: %w", name, err)
+}
+
+// Output runs git in dir and returns its trimmed output
+func (g *Git) Output(ctx context.Context, dir string, args ...string) (string, error) {
+	out, err := g.combined(ctx, dir, args...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Run runs git in dir, discarding its output unless it fails
+func (g *Git) Run(ctx context.Context, dir string, args ...string) error {
+	_, err := g.combined(ctx, dir, args...)
+	return err
+}
+
+// Clone clones url into dest, writing git's progress to progress if it's
+// non-nil. depth limits the history cloned; 0 clones all of it. If the clone
+// fails, whatever it left in dest is removed so a retry starts fresh; dest
+// must be missing or empty, so nothing else is ever removed.
+func (g *Git) Clone(ctx context.Context, url, dest string, depth int, progress io.Writer) error {
+	if url == "" {
+		return fmt.Errorf("app has no git URL to clone")
+	}
+
+	existed := false
+	if _, err := os.Stat(dest); err == nil {
+		if !IsEmptyDir(dest) {
+			return fmt.Errorf("can't clone into %s: it already exists and is not empty", dest)
+		}
+		existed = true
+	}
+
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+
+	args := []string{"clone"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+	}
+	args = append(args, url, dest)
+	if progress == nil {
+		progress = io.Discard
+	}
+
+	if err := g.Runner.Run(ctx, "", progress, progress, args...); err != nil {
+		RemovePartialClone(dest, existed)
+		if ctx.Err() != nil {
+			return g.error(ctx, args, nil, err)
+		}
+		return fmt.Errorf("failed to clone repo (if it is private, make sure git can authenticate without prompting, e.g. with a credential helper): %w", err)
+	}
+	return nil
+}
+
+// RemovePartialClone cleans up after a failed clone into dest, or one that
+// can't be installed, keeping dest itself if it existed beforehand
+func RemovePartialClone(dest string, existed bool) {
+	if !existed {
+		_ = os.RemoveAll(dest)
+		return
+	}
+	entries, err := os.ReadDir(dest)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		_ = os.RemoveAll(filepath.Join(dest, entry.Name()))
+	}
+}
+
+// IsEmptyDir reports whether dir is a directory with nothing in it
+func IsEmptyDir(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	return err == nil && len(entries) == 0
+}
+
+// Fetch fetches dir's remote. Extra args such as --tags or --unshallow are
+// passed to git fetch.
+func (g *Git) Fetch(ctx context.Context, dir string, args ...string) error {
+	return g.Run(ctx, dir, append([]string{"fetch", "--quiet"}, args...)...)
+}
+
+// Status returns git status --porcelain for dir; empty means clean
+func (g *Git) Status(ctx context.Context, dir string) (string, error) {
+	return g.Output(ctx, dir, "status", "--porcelain")
+}
+
+// RevParse resolves rev, e.g. HEAD or @{u}, to a commit hash
+func (g *Git) RevParse(ctx context.Context, dir, rev string) (string, error) {
+	return g.Output(ctx, dir, "rev-parse", rev)
+}
+
+// AheadBehind returns how many commits HEAD is ahead of and behind its
+// upstream branch. ok is false if there is no upstream.
+func (g *Git) AheadBehind(ctx context.Context, dir string) (ahead, behind int, ok bool) {
+	counts, err := g.Output(ctx, dir, "rev-list", "--left-right", "--count", "HEAD...@{u}")
+	if err != nil {
+		return 0, 0, false
+	}
+	return parseAheadBehind(counts)
+}
+
+func parseAheadBehind(counts string) (ahead, behind int, ok bool) {
+	parts := strings.Fields(counts)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+
+	ahead, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	behind, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return ahead, behind, true
+}
+
+// SummarizeStatus describes git status --porcelain output, e.g.
+// "3 files (2 modified, 1 untracked)"
+func SummarizeStatus(status string) string {
+	var modified, added, deleted, untracked int
+	for _, line := range strings.Split(status, "\n") {
+		if len(line) < 2 {
+			continue
+		}
+		switch code := line[:2]; {
+		case code == "??":
+			untracked++
+		case strings.Contains(code, "D"):
+			deleted++
+		case strings.Contains(code, "A"):
+			added++
+		default:
+			modified++
+		}
+	}
+
+	var parts []string
+	for _, c := range []struct {
+		n    int
+		desc string
+	}{{modified, "modified"}, {added, "added"}, {deleted, "deleted"}, {untracked, "untracked"}} {
+		if c.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c.n, c.desc))
+		}
+	}
+	if len(parts) == 0 {
+		return "no files changed"
+	}
+
+	files := "files"
+	if modified+added+deleted+untracked == 1 {
+		files = "file"
+	}
+	return fmt.Sprintf("%d %s (%s)", modified+added+deleted+untracked, files, strings.Join(parts, ", "))
+}
+
+// UnsavedWork describes what deleting dir would lose that isn't on its
+// remote: uncommitted changes, untracked files and unpushed commits. It
+// returns nil for a clean checkout or a directory git can't inspect.
+func (g *Git) UnsavedWork(ctx context.Context, dir string) []string {
+	status, err := g.Status(ctx, dir)
+	if err != nil {
+		return nil
+	}
+
+	var work []string
+	if status != "" {
+		work = append(work, "uncommitted changes: "+SummarizeStatus(status))
+	}
+	if ahead, _, ok := g.AheadBehind(ctx, dir); ok && ahead > 0 {
+		commits := "commits"
+		if ahead == 1 {
+			commits = "commit"
+		}
+		work = append(work, fmt.Sprintf("%d %s not pushed upstream", ahead, commits))
+	}
+	return work
+}
+
+// Stash stashes dir's changes, including untracked files, under message
+func (g *Git) Stash(ctx context.Context, dir, message string) error {
+	return g.Run(ctx, dir, "stash", "push", "-u", "-m", message)
+}
+
+// StashPop re-applies the latest stash. It fails, leaving the stash in
+// place, if the changes conflict.
+func (g *Git) StashPop(ctx context.Context, dir string) error {
+	return g.Run(ctx, dir, "stash", "pop")
+}
+
+// Pull fast-forwards dir to its upstream branch
+func (g *Git) Pull(ctx context.Context, dir string) error {
+	return g.Run(ctx, dir, "pull", "--ff-only")
+}
+
+// ParseRemote returns the org and repo of a GitHub, GitLab or Bitbucket
+// remote URL. ok is false for other URLs.
+func ParseRemote(url string) (org, repo string, ok bool) {
+	org, repo, ok = strings.Cut(giturl.ExtractOrgRepo(url), "/")
+	if !ok || org == "" || repo == "" {
+		return "", "", false
+	}
+	return org, repo, true
+}
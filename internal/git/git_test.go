@@ -0,0 +1,158 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// runnerFunc adapts a function to Runner
+type runnerFunc func(ctx context.Context, dir string, stdout, stderr io.Writer, args ...string) error
+
+func (f runnerFunc) Run(ctx context.Context, dir string, stdout, stderr io.Writer, args ...string) error {
+	return f(ctx, dir, stdout, stderr, args...)
+}
+
+func TestAheadBehind(t *testing.T) {
+	tests := []struct {
+		name          string
+		output        string
+		err           error
+		ahead, behind int
+		ok            bool
+	}{
+		{name: "behind", output: "0\t3\n", behind: 3, ok: true},
+		{name: "diverged", output: "2\t5", ahead: 2, behind: 5, ok: true},
+		{name: "no upstream", output: "fatal: no upstream configured", err: errors.New("exit status 128")},
+		{name: "garbage", output: "x y"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Git{Runner: runnerFunc(func(ctx context.Context, dir string, stdout, stderr io.Writer, args ...string) error {
+				io.WriteString(stdout, tt.output)
+				return tt.err
+			})}
+			ahead, behind, ok := g.AheadBehind(context.Background(), "/app")
+			if ahead != tt.ahead || behind != tt.behind || ok != tt.ok {
+				t.Errorf("AheadBehind() = %d, %d, %v; want %d, %d, %v", ahead, behind, ok, tt.ahead, tt.behind, tt.ok)
+			}
+		})
+	}
+}
+
+func TestUnsavedWork(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		counts string
+		err    error
+		want   []string
+	}{
+		{name: "clean", counts: "0\t0"},
+		{name: "not a repo", err: errors.New("exit status 128")},
+		{
+			name:   "dirty and ahead",
+			status: " M main.go\n?? notes.txt\n",
+			counts: "1\t0",
+			want:   []string{"uncommitted changes: 2 files (1 modified, 1 untracked)", "1 commit not pushed upstream"},
+		},
+		{name: "no upstream", status: "?? a\n?? b\n", want: []string{"uncommitted changes: 2 files (2 untracked)"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Git{Runner: runnerFunc(func(ctx context.Context, dir string, stdout, stderr io.Writer, args ...string) error {
+				switch args[0] {
+				case "status":
+					io.WriteString(stdout, tt.status)
+					return tt.err
+				case "rev-list":
+					if tt.counts == "" {
+						return errors.New("exit status 128")
+					}
+					io.WriteString(stdout, tt.counts)
+				}
+				return nil
+			})}
+			got := g.UnsavedWork(context.Background(), "/app")
+			if strings.Join(got, "; ") != strings.Join(tt.want, "; ") {
+				t.Errorf("UnsavedWork() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCloneRemovesPartialCheckout(t *testing.T) {
+	failingClone := runnerFunc(func(ctx context.Context, dir string, stdout, stderr io.Writer, args ...string) error {
+		dest := args[len(args)-1]
+		if err := os.MkdirAll(filepath.Join(dest, ".git"), 0755); err != nil {
+			return err
+		}
+		return errors.New("exit status 128")
+	})
+	g := &Git{Runner: failingClone}
+
+	t.Run("missing dest", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "app")
+		if err := g.Clone(context.Background(), "https://github.com/o/r", dest, 1, nil); err == nil {
+			t.Fatal("Clone() succeeded, want error")
+		}
+		if _, err := os.Stat(dest); !os.IsNotExist(err) {
+			t.Errorf("dest still exists after failed clone")
+		}
+	})
+
+	t.Run("empty dest", func(t *testing.T) {
+		dest := t.TempDir()
+		if err := g.Clone(context.Background(), "https://github.com/o/r", dest, 1, nil); err == nil {
+			t.Fatal("Clone() succeeded, want error")
+		}
+		if !IsEmptyDir(dest) {
+			t.Errorf("dest should be kept, and empty, after failed clone")
+		}
+	})
+
+	t.Run("non-empty dest", func(t *testing.T) {
+		dest := t.TempDir()
+		keep := filepath.Join(dest, "keep")
+		if err := os.WriteFile(keep, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := g.Clone(context.Background(), "https://github.com/o/r", dest, 1, nil); err == nil {
+			t.Fatal("Clone() succeeded, want error")
+		}
+		if _, err := os.Stat(keep); err != nil {
+			t.Errorf("existing file was removed: %v", err)
+		}
+	})
+}
+
+func TestTimeoutError(t *testing.T) {
+	g := &Git{
+		Timeout:     10 * time.Millisecond,
+		TimeoutHint: "raise the limit",
+		Runner: runnerFunc(func(ctx context.Context, dir string, stdout, stderr io.Writer, args ...string) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+	}
+	err := g.Fetch(context.Background(), "/app")
+	if err == nil || !strings.Contains(err.Error(), "timed out") || !strings.Contains(err.Error(), "raise the limit") {
+		t.Errorf("Fetch() error = %v, want a timeout with the hint", err)
+	}
+}
+
+func TestParseRemote(t *testing.T) {
+	if org, repo, ok := ParseRemote("git@github.com:acme/tool.git"); !ok || org != "acme" || repo != "tool" {
+		t.Errorf("ParseRemote() = %q, %q, %v", org, repo, ok)
+	}
+	if _, _, ok := ParseRemote("https://example.com/acme/tool"); ok {
+		t.Error("ParseRemote() accepted an unsupported host")
+	}
+}
@@ -1,6 +1,9 @@
 package giturl
 
-import "strings"
+import (
+	"net/url"
+	"strings"
+)
 
 // ExtractOrgRepo extracts org/repo from supported Git URLs.
 func ExtractOrgRepo(gitURL string) string {
@@ -19,3 +22,39 @@ func ExtractOrgRepo(gitURL string) string {
 	}
 	return ""
 }
+
+// HTTPS returns the https URL of a repository on a supported host given by
+// an ssh URL, e.g. git@github.com:org/repo.git. Other URLs are returned
+// without a .git suffix but otherwise unchanged.
+func HTTPS(gitURL string) string {
+	gitURL = strings.TrimSuffix(gitURL, ".git")
+	for _, host := range []string{"github.com", "gitlab.com", "bitbucket.org"} {
+		if orgRepo, ok := strings.CutPrefix(gitURL, "git@"+host+":"); ok {
+			return "https://" + host + "/" + orgRepo
+		}
+	}
+	return gitURL
+}
+
+// Same reports whether two Git URLs point at the same repository: the same
+// host and path, compared case-insensitively, regardless of protocol, e.g.
+// https and ssh, credentials, a .git suffix or a trailing slash.
+func Same(a, b string) bool {
+	keyA := repoKey(a)
+	return keyA != "" && strings.EqualFold(keyA, repoKey(b))
+}
+
+// repoKey returns the host and path of a Git URL, e.g. github.com/org/repo
+// for both https://github.com/org/repo and git@github.com:org/repo.git
+func repoKey(gitURL string) string {
+	gitURL = strings.TrimSuffix(strings.TrimSuffix(gitURL, "/"), ".git")
+	if rest, ok := strings.CutPrefix(gitURL, "git@"); ok && !strings.Contains(gitURL, "://") {
+		if host, path, ok := strings.Cut(rest, ":"); ok {
+			return host + "/" + strings.TrimPrefix(path, "/")
+		}
+	}
+	if u, err := url.Parse(gitURL); err == nil && u.Host != "" {
+		return u.Host + u.Path
+	}
+	return gitURL
+}
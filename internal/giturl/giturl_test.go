@@ -0,0 +1,66 @@
+package giturl
+
+import "testing"
+
+func TestExtractOrgRepo(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://github.com/acme/tool.git", "acme/tool"},
+		{"https://gitlab.com/acme/tool", "acme/tool"},
+		{"git@gitlab.com:acme/tool.git", "acme/tool"},
+		{"https://bitbucket.org/acme/tool", "acme/tool"},
+		{"git@bitbucket.org:acme/tool.git", "acme/tool"},
+		{"https://git.example.com/acme/tool.git", ""},
+	}
+	for _, tt := range tests {
+		if got := ExtractOrgRepo(tt.url); got != tt.want {
+			t.Errorf("ExtractOrgRepo(%q) = %q; want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestSame(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"https://gitlab.com/acme/tool", "git@gitlab.com:acme/tool.git", true},
+		{"https://bitbucket.org/Acme/Tool.git", "https://bitbucket.org/acme/tool", true},
+		{"https://gitlab.com/acme/tool", "https://gitlab.com/acme/other", false},
+		{"https://git.example.com/acme/tool.git", "https://git.example.com/acme/tool/", true},
+		{"https://git.example.com/acme/tool", "https://git.example.com/acme/other", false},
+		{"https://github.com/acme/tool", "https://gitlab.com/acme/tool", false},
+		{"git@gitlab.com:acme/tool.git", "https://github.com/acme/tool", false},
+		{"https://git.example.com/acme/tool", "https://git.other.com/acme/tool", false},
+		{"https://git.example.com/Acme/Tool", "git@git.example.com:acme/tool.git", true},
+		{"ssh://git@git.example.com/acme/tool.git", "https://token@git.example.com/acme/tool", true},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := Same(tt.a, tt.b); got != tt.want {
+			t.Errorf("Same(%q, %q) = %v; want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := Same(tt.b, tt.a); got != tt.want {
+			t.Errorf("Same(%q, %q) = %v; want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestHTTPS(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"git@github.com:acme/tool.git", "https://github.com/acme/tool"},
+		{"git@gitlab.com:acme/tool", "https://gitlab.com/acme/tool"},
+		{"https://bitbucket.org/acme/tool.git", "https://bitbucket.org/acme/tool"},
+		{"git@git.example.com:acme/tool.git", "git@git.example.com:acme/tool"},
+	}
+	for _, tt := range tests {
+		if got := HTTPS(tt.url); got != tt.want {
+			t.Errorf("HTTPS(%q) = %q; want %q", tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+// Package kioskmd finds an app's KIOSK.md, the manifest that tells Claude
+// how to install it.
+package kioskmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Names are the file names a KIOSK.md is accepted under, in the order they
+// are looked for
+var Names = []string{"KIOSK.md", "Kiosk.md", "kiosk.md"}
+
+// skipDirs are never searched: they hold dependencies, not apps
+var skipDirs = []string{"node_modules", "vendor"}
+
+// Find returns the path of the KIOSK.md directly in dir, or "" if it has
+// none
+func Find(dir string) string {
+	for _, name := range Names {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return path
+		}
+	}
+	return ""
+}
+
+// Exists reports whether dir has a KIOSK.md
+func Exists(dir string) bool {
+	return Find(dir) != ""
+}
+
+// Info is what a KIOSK.md says about its app
+type Info struct {
+	Name        string
+	Description string
+	Sandbox     []string // recommended kiosk run --sandbox values, from the frontmatter
+	Requires    []string // "runtime: constraint" entries of the frontmatter's requires mapping
+}
+
+// ReadInfo returns the name and description given in dir's KIOSK.md
+func ReadInfo(dir string) (Info, error) {
+	path := Find(dir)
+	if path == "" {
+		return Info{}, os.ErrNotExist
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Info{}, err
+	}
+	return ParseInfo(string(data)), nil
+}
+
+// ParseInfo takes the name, description, sandbox and requires from a
+// KIOSK.md's frontmatter keys of the same name. sandbox is a list, either
+// inline as [fs, net] or one "- value" per line. requires is a mapping, either
+// inline as {node: ">=18"} or one entry per indented line; its entries are
+// kept as written, and a value that isn't a mapping is kept as one entry, for
+// the requirements package to parse. Without a name or description, the name
+// is the first top-level heading and the description the paragraph that
+// follows it.
+func ParseInfo(content string) Info {
+	var info Info
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		inSandbox, inRequires := false, false
+		for i, line := range lines[1:] {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "---" {
+				lines = lines[i+2:]
+				break
+			}
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			if line[0] == ' ' || line[0] == '\t' || line[0] == '-' {
+				switch {
+				case inSandbox:
+					if item, ok := strings.CutPrefix(trimmed, "- "); ok {
+						info.Sandbox = append(info.Sandbox, unquote(item))
+					}
+				case inRequires:
+					info.Requires = append(info.Requires, trimmed)
+				}
+				continue
+			}
+			inSandbox, inRequires = false, false
+			key, value, ok := strings.Cut(line, ":")
+			if !ok {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			switch strings.TrimSpace(key) {
+			case "name":
+				info.Name = unquote(value)
+			case "description":
+				info.Description = unquote(value)
+			case "sandbox":
+				if value == "" {
+					inSandbox = true
+					break
+				}
+				for _, item := range strings.Split(strings.Trim(value, "[]"), ",") {
+					if item = unquote(strings.TrimSpace(item)); item != "" {
+						info.Sandbox = append(info.Sandbox, item)
+					}
+				}
+			case "requires":
+				if value == "" {
+					inRequires = true
+					break
+				}
+				if body, ok := strings.CutPrefix(value, "{"); ok && strings.HasSuffix(body, "}") {
+					info.Requires = append(info.Requires, splitInline(strings.TrimSuffix(body, "}"))...)
+				} else {
+					info.Requires = append(info.Requires, value)
+				}
+			}
+		}
+	}
+
+	var title string
+	var paragraph []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if title == "" {
+			if heading, ok := strings.CutPrefix(line, "# "); ok {
+				title = strings.TrimSpace(heading)
+			}
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "```") {
+			if len(paragraph) > 0 {
+				break
+			}
+			continue
+		}
+		paragraph = append(paragraph, line)
+	}
+	if info.Name == "" {
+		info.Name = title
+	}
+	if info.Description == "" {
+		info.Description = strings.Join(paragraph, " ")
+	}
+	return info
+}
+
+// splitInline splits the body of an inline mapping into its entries, on
+// commas that aren't inside quotes, so python: ">=3.10, <4" stays one entry
+func splitInline(s string) []string {
+	var parts []string
+	var quote rune
+	start := 0
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ',':
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, s[start:])
+
+	var entries []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			entries = append(entries, p)
+		}
+	}
+	return entries
+}
+
+// unquote strips the quotes around a YAML scalar
+func unquote(s string) string {
+	return strings.Trim(s, `"'`)
+}
+
+// Search returns the directories under dir, at most depth levels down, that
+// have a KIOSK.md, as sorted slash-separated paths relative to dir. dir
+// itself is not included. Hidden directories and dependency directories
+// such as node_modules are skipped.
+func Search(dir string, depth int) []string {
+	var found []string
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !d.IsDir() || path == dir {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return filepath.SkipDir
+		}
+		if strings.HasPrefix(d.Name(), ".") || slices.Contains(skipDirs, d.Name()) {
+			return filepath.SkipDir
+		}
+		if Exists(path) {
+			found = append(found, filepath.ToSlash(rel))
+		}
+		if strings.Count(filepath.ToSlash(rel), "/")+1 >= depth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	slices.Sort(found)
+	return found
+}
@@ -0,0 +1,84 @@
+package kioskmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	root := t.TempDir()
+	for _, path := range []string{
+		"KIOSK.md",
+		"apps/web/kiosk.md",
+		"apps/cli/Kiosk.md",
+		"apps/deep/nested/too/KIOSK.md",
+		"node_modules/pkg/KIOSK.md",
+		".hidden/KIOSK.md",
+		"docs/README.md",
+	} {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("# app\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !Exists(root) {
+		t.Error("Exists() = false for a directory with KIOSK.md")
+	}
+	if Exists(filepath.Join(root, "docs")) {
+		t.Error("Exists() = true for a directory without one")
+	}
+
+	got := Search(root, 2)
+	want := []string{"apps/cli", "apps/web"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Search() = %v; want %v", got, want)
+	}
+}
+
+func TestParseInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Info
+	}{
+		{"heading", "# Weather\n\nShows the forecast\nfor your city.\n\n## Install\n\nRun it.\n", Info{Name: "Weather", Description: "Shows the forecast for your city."}},
+		{"frontmatter", "---\nname: Weather\ndescription: \"Shows the forecast\"\nrequires:\n  node: \">=20\"\n---\n# Something else\n\nIgnored.\n", Info{Name: "Weather", Description: "Shows the forecast", Requires: []string{`node: ">=20"`}}},
+		{"inline requires", "---\nrequires: {node: \">=18\", python: \">=3.10, <4\"}\n---\n", Info{Requires: []string{`node: ">=18"`, `python: ">=3.10, <4"`}}},
+		{"frontmatter name only", "---\nname: Weather\n---\n# Title\n\nFrom the body.\n", Info{Name: "Weather", Description: "From the body."}},
+		{"no heading", "Just instructions.\n", Info{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseInfo(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseInfo() = %+v; want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInfoSandbox(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"inline", "---\nsandbox: [fs, \"net:api.example.com\"]\n---\n# App\n", []string{"fs", "net:api.example.com"}},
+		{"block", "---\nsandbox:\n  - fs\n  - net\nname: App\n---\n", []string{"fs", "net"}},
+		{"unindented block", "---\nsandbox:\n- default\n---\n", []string{"default"}},
+		{"none", "---\nname: App\n---\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseInfo(tt.content).Sandbox; !slices.Equal(got, tt.want) {
+				t.Errorf("ParseInfo().Sandbox = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package prefetch
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -11,8 +12,32 @@ import (
 	"github.com/reflective-technologies/kiosk-cli/internal/config"
 )
 
-// DefaultPageSize is the number of apps to fetch per page
-const DefaultPageSize = 10
+// Page size bounds for browse fetches. DefaultPageSize is used when
+// browse.pageSize isn't configured and the screen size isn't known yet.
+const (
+	DefaultPageSize = 10
+	MinPageSize     = 5
+	MaxPageSize     = 100
+)
+
+// PageSize returns the number of apps to fetch per page. A configured
+// browse.pageSize wins; otherwise pages are sized to fill visibleRows, the
+// number of items the list can show at once (0 if not known yet). The result
+// is clamped to [MinPageSize, MaxPageSize].
+func PageSize(cfg *config.Config, visibleRows int) int {
+	size := DefaultPageSize
+	if cfg != nil && cfg.Browse.PageSize > 0 {
+		size = cfg.Browse.PageSize
+	} else if visibleRows > size {
+		size = visibleRows
+	}
+	return min(max(size, MinPageSize), MaxPageSize)
+}
+
+// BrowseRetryInterval is how long a failed browse fetch is remembered before
+// opening Browse again retries it, so an unreachable API isn't asked again
+// on every visit
+const BrowseRetryInterval = 30 * time.Second
 
 // Cache holds prefetched data for the TUI views.
 // It is safe for concurrent access.
@@ -23,7 +48,14 @@ type Cache struct {
 	browseApps       []api.App
 	browseNextCursor *string // cursor for next page, nil if no more pages
 	browseAppsErr    error
+	browseFailedAt   time.Time // when browseAppsErr happened
 	browseLoaded     bool
+
+	// In-flight browse fetch. generation is bumped on every start and reset
+	// so a superseded fetch can't overwrite newer state.
+	browseFetching bool
+	browseCancel   context.CancelFunc
+	generation     int
 }
 
 // global cache instance
@@ -34,35 +66,68 @@ func GetCache() *Cache {
 	return globalCache
 }
 
-// StartBrowseAppsPrefetch begins fetching the first page of browse apps in the background.
+// StartBrowseAppsPrefetch begins fetching the first page of browse apps, of
+// pageSize apps, in the background.
 // This should be called early in the TUI lifecycle (e.g., during Init).
-func (c *Cache) StartBrowseAppsPrefetch() {
-	go c.fetchBrowseApps()
+// It does nothing if a fetch is already in flight or the apps are already
+// loaded; call ResetBrowseApps first to force a fresh fetch.
+func (c *Cache) StartBrowseAppsPrefetch(pageSize int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.browseFetching || c.browseLoaded {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.generation++
+	c.browseFetching = true
+	c.browseCancel = cancel
+	go c.fetchBrowseApps(ctx, c.generation, pageSize)
+}
+
+// Cancel stops any in-flight prefetch. Callers waiting on the result get
+// context.Canceled. This should be called when the TUI exits.
+func (c *Cache) Cancel() {
+	c.mu.RLock()
+	cancel := c.browseCancel
+	c.mu.RUnlock()
+
+	if cancel != nil {
+		cancel()
+	}
 }
 
 // fetchBrowseApps fetches the first page of browse apps from the API.
-func (c *Cache) fetchBrowseApps() {
+func (c *Cache) fetchBrowseApps(ctx context.Context, generation, pageSize int) {
+	var result *api.PaginatedAppsResponse
 	cfg, err := config.Load()
-	if err != nil {
-		c.mu.Lock()
-		c.browseAppsErr = err
-		c.browseLoaded = true
-		c.mu.Unlock()
-		return
+	if err == nil {
+		client := api.NewClient(cfg.APIUrl).WithRegistry(cfg.Registry)
+		result, err = client.ListAppsPaginatedContext(ctx, pageSize, "")
+	}
+	if ctx.Err() != nil {
+		err = ctx.Err()
 	}
-
-	client := api.NewClient(cfg.APIUrl)
-	result, err := client.ListAppsPaginated(DefaultPageSize, "")
 
 	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		// Reset while in flight; the result is stale
+		return
+	}
 	if err != nil {
 		c.browseAppsErr = err
+		c.browseFailedAt = time.Now()
 	} else {
 		c.browseApps = result.Apps
 		c.browseNextCursor = result.NextCursor
 	}
 	c.browseLoaded = true
-	c.mu.Unlock()
+	c.browseFetching = false
+	c.browseCancel()
+	c.browseCancel = nil
 }
 
 // BrowseAppsResult contains the result of the browse apps prefetch.
@@ -70,9 +135,16 @@ type BrowseAppsResult struct {
 	Apps       []api.App
 	NextCursor *string // cursor for next page, nil if no more pages
 	Err        error
+	FailedAt   time.Time // when Err happened
 	Loaded     bool
 }
 
+// RetryDue reports whether a failed fetch is old enough to retry without
+// being asked to; see BrowseRetryInterval.
+func (r BrowseAppsResult) RetryDue() bool {
+	return r.Err != nil && time.Since(r.FailedAt) >= BrowseRetryInterval
+}
+
 // GetBrowseApps returns the prefetched browse apps if available.
 // If the data hasn't been fetched yet, Loaded will be false.
 func (c *Cache) GetBrowseApps() BrowseAppsResult {
@@ -83,6 +155,7 @@ func (c *Cache) GetBrowseApps() BrowseAppsResult {
 		Apps:       c.browseApps,
 		NextCursor: c.browseNextCursor,
 		Err:        c.browseAppsErr,
+		FailedAt:   c.browseFailedAt,
 		Loaded:     c.browseLoaded,
 	}
 }
@@ -104,23 +177,25 @@ func (c *Cache) WaitForBrowseApps() BrowseAppsResult {
 
 // Reset clears all cached data. Useful for testing or when data needs to be refreshed.
 func (c *Cache) Reset() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	c.browseApps = nil
-	c.browseNextCursor = nil
-	c.browseAppsErr = nil
-	c.browseLoaded = false
+	c.ResetBrowseApps()
 }
 
 // ResetBrowseApps clears only the browse apps cache, allowing a fresh fetch.
 // This is useful when the previous fetch failed and the user wants to retry.
+// Any fetch still in flight is cancelled.
 func (c *Cache) ResetBrowseApps() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if c.browseCancel != nil {
+		c.browseCancel()
+		c.browseCancel = nil
+	}
+	c.generation++
+	c.browseFetching = false
 	c.browseApps = nil
 	c.browseNextCursor = nil
 	c.browseAppsErr = nil
+	c.browseFailedAt = time.Time{}
 	c.browseLoaded = false
 }
@@ -0,0 +1,361 @@
+// Package requirements checks that the language runtimes an app declares in its
+// KIOSK.md frontmatter are installed at a suitable version, e.g.
+//
+//	---
+//	requires:
+//	  node: ">=18"
+//	  python: ">=3.10, <4"
+//	---
+//
+// The inline form requires: {node: ">=18"} is accepted too. Only the
+// runtimes in versionCommands are looked up; KIOSK.md comes from the app, so
+// other names are reported as unknown rather than run.
+package requirements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/kioskmd"
+)
+
+// versionTimeout bounds how long a runtime's version command may take
+const versionTimeout = 5 * time.Second
+
+// Requirement is a runtime an app needs, with an optional version constraint
+type Requirement struct {
+	Name       string // e.g. "node"
+	Constraint string // e.g. ">=18"; empty accepts any version
+}
+
+func (r Requirement) String() string {
+	if r.Constraint == "" {
+		return r.Name
+	}
+	return r.Name + " " + r.Constraint
+}
+
+// Result is the outcome of checking one Requirement
+type Result struct {
+	Requirement
+	Unknown   bool   // not a runtime kiosk knows how to check; nothing was run
+	Found     bool   // the runtime is on PATH
+	Version   string // detected version; empty if it couldn't be determined
+	Satisfied bool   // found, and the version meets the constraint
+}
+
+// Problem describes an unmet requirement, or "" if it is met
+func (r Result) Problem() string {
+	switch {
+	case r.Unknown:
+		return fmt.Sprintf("%s is required but kiosk can't check it", r.Requirement)
+	case !r.Found:
+		return fmt.Sprintf("%s is required but not installed", r.Requirement)
+	case r.Satisfied:
+		return ""
+	case r.Version == "":
+		return fmt.Sprintf("%s is required but its version could not be determined", r.Requirement)
+	default:
+		return fmt.Sprintf("%s is required but %s is installed", r.Requirement, r.Version)
+	}
+}
+
+// ReadRequirements returns the runtimes declared in dir's KIOSK.md. An app
+// without a KIOSK.md or a requires block has no requirements.
+func ReadRequirements(dir string) ([]Requirement, error) {
+	path := kioskmd.Find(dir)
+	if path == "" {
+		return nil, nil
+	}
+	name := filepath.Base(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	reqs, err := ParseRequirements(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid requires in %s: %w", name, err)
+	}
+	return reqs, nil
+}
+
+// ParseRequirements returns the requires mapping from a KIOSK.md's
+// frontmatter, as read by kioskmd.ParseInfo, checking each entry's
+// constraint
+func ParseRequirements(content string) ([]Requirement, error) {
+	var reqs []Requirement
+	for _, entry := range kioskmd.ParseInfo(content).Requires {
+		req, err := parseEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := ParseConstraint(req.Constraint); err != nil {
+			return nil, fmt.Errorf("%s: %w", req.Name, err)
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs, nil
+}
+
+// parseEntry parses a "name: constraint" mapping entry
+func parseEntry(entry string) (Requirement, error) {
+	name, value, ok := strings.Cut(entry, ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return Requirement{}, fmt.Errorf("expected name: version, got %q", strings.TrimSpace(entry))
+	}
+	value = strings.TrimSpace(value)
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	} else if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		value = value[1 : len(value)-1]
+	}
+	return Requirement{Name: name, Constraint: strings.TrimSpace(value)}, nil
+}
+
+// Check looks up each requirement's runtime and compares its version
+// against the constraint
+func Check(reqs []Requirement) []Result {
+	results := make([]Result, 0, len(reqs))
+	for _, req := range reqs {
+		result := Result{Requirement: req}
+		if _, ok := versionCommands[req.Name]; !ok {
+			result.Unknown = true
+			results = append(results, result)
+			continue
+		}
+		version, err := detectVersion(req.Name)
+		if err == nil {
+			result.Found = true
+			result.Version = version
+			c, _ := ParseConstraint(req.Constraint)
+			result.Satisfied = c.Allows(version)
+		} else if !errors.Is(err, errNotInstalled) {
+			// Installed, but the version command failed or printed nothing
+			// we recognise; only a constraint-free requirement is met
+			result.Found = true
+			result.Satisfied = req.Constraint == ""
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// versionCommands maps the runtimes kiosk checks to the command that prints
+// their version. It is an allowlist: requirements naming anything else are
+// never executed.
+var versionCommands = map[string][]string{
+	"bun":    {"bun", "--version"},
+	"cargo":  {"cargo", "--version"},
+	"deno":   {"deno", "--version"},
+	"docker": {"docker", "--version"},
+	"git":    {"git", "--version"},
+	"go":     {"go", "version"},
+	"java":   {"java", "-version"},
+	"node":   {"node", "--version"},
+	"npm":    {"npm", "--version"},
+	"php":    {"php", "--version"},
+	"pnpm":   {"pnpm", "--version"},
+	"python": {"python3", "--version"},
+	"ruby":   {"ruby", "--version"},
+	"rustc":  {"rustc", "--version"},
+	"uv":     {"uv", "--version"},
+	"yarn":   {"yarn", "--version"},
+}
+
+// errNotInstalled is returned by detectVersion when the runtime isn't on PATH
+var errNotInstalled = errors.New("not installed")
+
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)*`)
+
+// detectVersion runs a runtime's version command and returns the first
+// version number in its output. It is a variable so tests can stub it.
+var detectVersion = func(name string) (string, error) {
+	cmd, ok := versionCommands[name]
+	if !ok {
+		return "", fmt.Errorf("%s is not a runtime kiosk checks", name)
+	}
+	args := append([]string(nil), cmd...)
+	path, err := exec.LookPath(args[0])
+	if err != nil && name == "python" {
+		args[0] = "python"
+		path, err = exec.LookPath(args[0])
+	}
+	if err != nil {
+		return "", errNotInstalled
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), versionTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, args[1:]...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s failed: %w", strings.Join(args, " "), err)
+	}
+
+	version := versionPattern.FindString(string(out))
+	if version == "" {
+		return "", fmt.Errorf("no version in output of %s", strings.Join(args, " "))
+	}
+	return version, nil
+}
+
+// Constraint is a set of version comparisons that must all hold
+type Constraint []comparison
+
+type comparison struct {
+	op      string // one of >=, >, <=, <, =; ^ and ~ become a pair of these
+	version []int
+}
+
+var constraintOps = []string{">=", "<=", ">", "<", "=", "^", "~"}
+
+// opSpace matches the space some authors leave after an operator, as in
+// ">= 18"
+var opSpace = regexp.MustCompile(`([<>=^~])\s+`)
+
+// ParseConstraint parses a constraint such as ">=18", ">= 18", ">=3.10, <4",
+// or "20". A bare version matches that version and anything more specific
+// under it, so "20" accepts 20.11.1. As with npm, "^18" accepts anything from
+// 18 up to the next major version and "~3.11" anything from 3.11 up to the
+// next minor version. An empty constraint or "*" accepts any version.
+func ParseConstraint(s string) (Constraint, error) {
+	var c Constraint
+	normalized := opSpace.ReplaceAllString(s, "$1")
+	for _, part := range strings.FieldsFunc(normalized, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if part == "*" {
+			continue
+		}
+		op := ""
+		for _, candidate := range constraintOps {
+			if strings.HasPrefix(part, candidate) {
+				op = candidate
+				break
+			}
+		}
+		rest := strings.TrimPrefix(strings.TrimPrefix(part, op), "v")
+		version, ok := parseNumbers(rest)
+		if !ok {
+			return nil, fmt.Errorf("invalid version constraint %q", s)
+		}
+		switch op {
+		case "^":
+			c = append(c, comparison{op: ">=", version: version}, comparison{op: "<", version: caretBound(version)})
+		case "~":
+			c = append(c, comparison{op: ">=", version: version}, comparison{op: "<", version: tildeBound(version)})
+		case "":
+			c = append(c, comparison{op: "=", version: version})
+		default:
+			c = append(c, comparison{op: op, version: version})
+		}
+	}
+	return c, nil
+}
+
+// caretBound is the exclusive upper bound of ^version: the next release
+// that changes its first non-zero component, so ^18.2 stops at 19 and
+// ^0.2.3 at 0.3
+func caretBound(version []int) []int {
+	i := len(version) - 1
+	for j, n := range version {
+		if n != 0 {
+			i = j
+			break
+		}
+	}
+	bound := slices.Clone(version[:i+1])
+	bound[i]++
+	return bound
+}
+
+// tildeBound is the exclusive upper bound of ~version: the next minor
+// version, or the next major one if only a major version is given
+func tildeBound(version []int) []int {
+	if len(version) == 1 {
+		return []int{version[0] + 1}
+	}
+	return []int{version[0], version[1] + 1}
+}
+
+// Allows reports whether version satisfies every comparison in c
+func (c Constraint) Allows(version string) bool {
+	v, ok := parseNumbers(strings.TrimPrefix(version, "v"))
+	if !ok {
+		return len(c) == 0
+	}
+	for _, cmp := range c {
+		if !cmp.allows(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c comparison) allows(v []int) bool {
+	if c.op == "=" {
+		// Compare only as many components as the constraint gives
+		return compareNumbers(truncate(v, len(c.version)), c.version) == 0
+	}
+	n := compareNumbers(v, c.version)
+	switch c.op {
+	case ">=":
+		return n >= 0
+	case ">":
+		return n > 0
+	case "<=":
+		return n <= 0
+	default:
+		return n < 0
+	}
+}
+
+func truncate(v []int, n int) []int {
+	if len(v) > n {
+		return v[:n]
+	}
+	return v
+}
+
+// parseNumbers parses a dotted version like 3.10.2
+func parseNumbers(s string) ([]int, bool) {
+	if s == "" {
+		return nil, false
+	}
+	var nums []int
+	for _, part := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		nums = append(nums, n)
+	}
+	return nums, true
+}
+
+// compareNumbers compares dotted versions, treating missing components as 0
+func compareNumbers(a, b []int) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
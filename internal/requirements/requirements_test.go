@@ -0,0 +1,154 @@
+package requirements
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRequirements(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Requirement
+		wantErr bool
+	}{
+		{
+			name:    "no frontmatter",
+			content: "# My App\n\nrequires: node\n",
+			want:    nil,
+		},
+		{
+			name:    "block mapping",
+			content: "---\ntitle: My App\nrequires:\n  node: \">=18\"\n  python: '>=3.10, <4'\nauthor: me\n---\n# My App\n",
+			want: []Requirement{
+				{Name: "node", Constraint: ">=18"},
+				{Name: "python", Constraint: ">=3.10, <4"},
+			},
+		},
+		{
+			name:    "inline mapping",
+			content: "---\nrequires: {node: \">=18\", bun: \"\"}\n---\n",
+			want: []Requirement{
+				{Name: "node", Constraint: ">=18"},
+				{Name: "bun", Constraint: ""},
+			},
+		},
+		{
+			name:    "caret and spaced constraints",
+			content: "---\nrequires:\n  node: ^18\n  python: \">= 3.10\"\n---\n",
+			want: []Requirement{
+				{Name: "node", Constraint: "^18"},
+				{Name: "python", Constraint: ">= 3.10"},
+			},
+		},
+		{
+			name:    "requires after frontmatter ignored",
+			content: "---\ntitle: x\n---\nrequires:\n  node: 18\n",
+			want:    nil,
+		},
+		{
+			name:    "invalid constraint",
+			content: "---\nrequires:\n  node: latest\n---\n",
+			wantErr: true,
+		},
+		{
+			name:    "scalar requires",
+			content: "---\nrequires: node\n---\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRequirements(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRequirements() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRequirements() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraintAllows(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"", "1.0.0", true},
+		{"*", "1.0.0", true},
+		{">=18", "18.0.0", true},
+		{">=18", "20.11.1", true},
+		{">=18", "16.20.2", false},
+		{">=3.10, <4", "3.9.18", false},
+		{">=3.10, <4", "3.12.1", true},
+		{">=3.10, <4", "4.0", false},
+		{"20", "20.11.1", true},
+		{"20", "21.0.0", false},
+		{"=1.22", "1.22.5", true},
+		{">1.2", "1.2.0", false},
+		{"<=v2", "2.0.0", true},
+		{">= 18", "18.1.0", true},
+		{">= 18", "16.0.0", false},
+		{">= 3.10, < 4", "3.11.2", true},
+		{"^18", "18.19.0", true},
+		{"^18", "19.0.0", false},
+		{"^18", "17.9.0", false},
+		{"^ 18.2", "18.1.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"~3.11", "3.11.4", true},
+		{"~3.11", "3.12.0", false},
+		{"~3", "3.9.1", true},
+		{"~3", "4.0.0", false},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Fatalf("ParseConstraint(%q) error = %v", tt.constraint, err)
+		}
+		if got := c.Allows(tt.version); got != tt.want {
+			t.Errorf("%q.Allows(%q) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	orig := detectVersion
+	defer func() { detectVersion = orig }()
+	detectVersion = func(name string) (string, error) {
+		switch name {
+		case "./payload":
+			t.Errorf("detectVersion(%q) called for a runtime outside the allowlist", name)
+			return "", nil
+		case "node":
+			return "16.20.2", nil
+		case "python":
+			return "3.12.1", nil
+		default:
+			return "", errNotInstalled
+		}
+	}
+
+	results := Check([]Requirement{
+		{Name: "node", Constraint: ">=18"},
+		{Name: "python", Constraint: ">=3.10"},
+		{Name: "deno"},
+		{Name: "./payload", Constraint: "*"},
+	})
+
+	want := []string{
+		"node >=18 is required but 16.20.2 is installed",
+		"",
+		"deno is required but not installed",
+		"./payload * is required but kiosk can't check it",
+	}
+	for i, r := range results {
+		if got := r.Problem(); got != want[i] {
+			t.Errorf("results[%d].Problem() = %q, want %q", i, got, want[i])
+		}
+	}
+}
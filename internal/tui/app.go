@@ -2,15 +2,20 @@ package tui
 
 import (
 	"os"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
 	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	"github.com/reflective-technologies/kiosk-cli/internal/events"
 	"github.com/reflective-technologies/kiosk-cli/internal/prefetch"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/components"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
 )
 
@@ -23,8 +28,17 @@ type Model struct {
 	keys        KeyMap
 	help        help.Model
 	showHelp    bool
+
+	// Transient status bar message; see setStatus
+	statusBar   components.StatusBar
 	status      string
-	err         error
+	statusIsErr bool
+	statusID    uint64
+
+	// Idle timeout; see SetIdleTimeout
+	idleTimeout time.Duration
+	idleID      uint64
+	idleArmed   bool
 
 	// App to execute after TUI exits (set when user clicks Run)
 	ExecApp string
@@ -49,16 +63,44 @@ type Model struct {
 	LoginView       tea.Model
 	AuditView       tea.Model
 	PostInstallView tea.Model
+	OnboardingView  tea.Model
 }
 
-// New creates a new TUI application model
-func New() Model {
+// New creates a new TUI application model; spinnerStyle is the configured
+// spinner for the status bar
+func New(spinnerStyle spinner.Spinner) Model {
 	return Model{
 		currentView: ViewHome,
 		viewStack:   []ViewType{},
 		keys:        DefaultKeyMap(),
 		help:        help.New(),
+		statusBar:   components.NewStatusBar(0, spinnerStyle),
+	}
+}
+
+const (
+	// statusTimeout is how long a status message stays up by default
+	statusTimeout = 3 * time.Second
+	// errorStatusTimeout gives errors longer, since they may need reading
+	errorStatusTimeout = 6 * time.Second
+)
+
+// setStatus shows message in the status bar and returns a command that
+// clears it after timeout, or the default for the message kind if zero
+func (m *Model) setStatus(message string, isErr bool, timeout time.Duration) tea.Cmd {
+	m.statusID++
+	m.status = message
+	m.statusIsErr = isErr
+	if timeout <= 0 {
+		timeout = statusTimeout
+		if isErr {
+			timeout = errorStatusTimeout
+		}
 	}
+	id := m.statusID
+	return tea.Tick(timeout, func(time.Time) tea.Msg {
+		return ClearStatusMsg{ID: id}
+	})
 }
 
 // SetHomeView sets the home view model
@@ -106,6 +148,32 @@ func (m *Model) SetPostInstallView(v tea.Model) {
 	m.PostInstallView = v
 }
 
+// SetOnboardingView sets the first-run onboarding view model
+func (m *Model) SetOnboardingView(v tea.Model) {
+	m.OnboardingView = v
+}
+
+// StartAt makes view the first view shown, in place of home
+func (m *Model) StartAt(view ViewType) {
+	m.currentView = view
+}
+
+// SetIdleTimeout makes the TUI return to home after d without a keypress,
+// and quit if it is idle at home. Zero disables it.
+func (m *Model) SetIdleTimeout(d time.Duration) {
+	m.idleTimeout = d
+}
+
+// armIdle restarts the idle timer, invalidating any pending IdleMsg
+func (m *Model) armIdle() tea.Cmd {
+	m.idleID++
+	m.idleArmed = true
+	id := m.idleID
+	return tea.Tick(m.idleTimeout, func(time.Time) tea.Msg {
+		return IdleMsg{ID: id}
+	})
+}
+
 // SetRunAppHandler sets the handler for executing apps from within the TUI.
 func (m *Model) SetRunAppHandler(handler func(RunAppMsg) tea.Cmd) {
 	m.RunAppHandler = handler
@@ -127,11 +195,14 @@ func (m *Model) Init() tea.Cmd {
 
 	// Start prefetching browse apps in the background
 	// so they're ready when the user navigates to Browse Apps
-	prefetch.GetCache().StartBrowseAppsPrefetch()
+	cfg, _ := config.Load()
+	prefetch.GetCache().StartBrowseAppsPrefetch(prefetch.PageSize(cfg, 0))
 
-	// Initialize the home view
-	if m.HomeView != nil {
-		cmds = append(cmds, m.HomeView.Init())
+	// Initialize the first view
+	cmds = append(cmds, m.initCurrentView())
+
+	if m.idleTimeout > 0 {
+		cmds = append(cmds, m.armIdle())
 	}
 
 	return tea.Batch(cmds...)
@@ -141,6 +212,20 @@ func (m *Model) Init() tea.Cmd {
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	// Input restarts the idle timer, as does the first message after an app
+	// session hands the terminal back
+	if m.idleTimeout > 0 {
+		switch msg.(type) {
+		case tea.KeyMsg, tea.MouseMsg:
+			cmds = append(cmds, m.armIdle())
+		case IdleMsg, RunAppMsg:
+		default:
+			if !m.idleArmed {
+				cmds = append(cmds, m.armIdle())
+			}
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		if msg.Width == 0 || msg.Height == 0 {
@@ -149,19 +234,20 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.help.Width = msg.Width
+		m.statusBar.SetWidth(msg.Width)
 
 		// Update size for all views using the Sizer interface
 		m.updateViewSizes()
 
 	case tea.KeyMsg:
 		// Global key handling
-		switch msg.String() {
-		case "ctrl+c", "q":
-			// Only quit from home view
-			if m.currentView == ViewHome {
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			// Only quit from home and onboarding views
+			if m.currentView == ViewHome || m.currentView == ViewOnboarding {
 				return m, tea.Quit
 			}
-		case "?":
+		case key.Matches(msg, m.keys.Help):
 			m.showHelp = !m.showHelp
 		}
 
@@ -171,7 +257,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case GoBackMsg:
 		m.goBack()
-		cmds = append(cmds, m.initCurrentView())
+		cmds = append(cmds, m.resumeCurrentView())
 
 	case ShowAppDetailMsg:
 		// Navigate to app detail and pass the app data
@@ -187,6 +273,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case RunAppMsg:
 		if m.RunAppHandler != nil {
+			// The app has the terminal until it exits, so nobody is idle
+			m.idleID++
+			m.idleArmed = false
 			return m, m.RunAppHandler(msg)
 		}
 		// Store the app key to execute after TUI exits
@@ -199,37 +288,53 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case AppRemovedMsg:
 		if msg.Err != nil {
-			m.err = msg.Err
+			cmds = append(cmds, m.setStatus("Error: "+msg.Err.Error(), true, 0))
 		} else {
-			m.status = "App removed successfully"
+			cmds = append(cmds, m.setStatus("App removed successfully", false, 0))
 			// Go back to previous view and refresh
 			m.goBack()
-			cmds = append(cmds, m.initCurrentView())
+			cmds = append(cmds, m.resumeCurrentView())
 		}
 
 	case ErrorMsg:
-		m.err = msg.Err
+		if msg.Err != nil {
+			cmds = append(cmds, m.setStatus("Error: "+msg.Err.Error(), true, 0))
+		}
 
 	case StatusMsg:
-		m.status = msg.Message
-		if msg.Timeout > 0 {
-			cmds = append(cmds, tea.Tick(msg.Timeout, func(time.Time) tea.Msg {
-				return ClearStatusMsg{}
-			}))
+		cmds = append(cmds, m.setStatus(msg.Message, false, msg.Timeout))
+
+	case IdleMsg:
+		if msg.ID != m.idleID {
+			break
+		}
+		// Audits and installs run without input; wait for them to finish
+		if b, ok := m.activeView().(BusyReporter); ok && b.Busy() {
+			cmds = append(cmds, m.armIdle())
+			break
 		}
+		if m.currentView == ViewHome {
+			return m, tea.Quit
+		}
+		// Leave whatever was half done for the next person, stopping work
+		// such as a pending login that would otherwise finish for them
+		m.leaveViews()
+		m.currentView = ViewHome
+		m.viewStack = []ViewType{}
+		m.showHelp = false
+		cmds = append(cmds, m.initCurrentView(), m.armIdle())
+		cmds = append(cmds, m.setStatus("Returned home after "+m.idleTimeout.String()+" without input", false, 0))
 
 	case ClearStatusMsg:
-		m.status = ""
+		if msg.ID == m.statusID {
+			m.status = ""
+			m.statusIsErr = false
+		}
 
 	case SessionSuspendedMsg:
 		m.goToAppListRoot()
-		m.status = msg.Message
+		cmds = append(cmds, m.setStatus(msg.Message, false, msg.Timeout))
 		cmds = append(cmds, m.initCurrentView())
-		if msg.Timeout > 0 {
-			cmds = append(cmds, tea.Tick(msg.Timeout, func(time.Time) tea.Msg {
-				return ClearStatusMsg{}
-			}))
-		}
 	}
 
 	// Update the current view
@@ -244,6 +349,44 @@ type Sizer interface {
 	SetSize(width, height int)
 }
 
+// Resumer is implemented by views that keep their place, such as the
+// selected item, when navigated back to instead of starting over
+type Resumer interface {
+	Resume() tea.Cmd
+}
+
+// Leaver is implemented by views with background work, such as polling for a
+// login, to stop when they're left without going through their own keys
+type Leaver interface {
+	Leave()
+}
+
+// BusyReporter is implemented by views that can be partway through work
+// that runs without input, such as an audit, which the idle timeout waits
+// for
+type BusyReporter interface {
+	Busy() bool
+}
+
+// leaveViews tells the current view and every view under it that they're
+// being left
+func (m *Model) leaveViews() {
+	for _, v := range append(slices.Clone(m.viewStack), m.currentView) {
+		if l, ok := m.view(v).(Leaver); ok {
+			l.Leave()
+		}
+	}
+}
+
+// resumeCurrentView resumes the current view after going back to it, or
+// initializes it if it can't resume
+func (m *Model) resumeCurrentView() tea.Cmd {
+	if r, ok := m.activeView().(Resumer); ok {
+		return r.Resume()
+	}
+	return m.initCurrentView()
+}
+
 func (m *Model) updateViewSizes() {
 	// Calculate content area (accounting for padding applied in View())
 	// Padding(1, 2) means 1 line top/bottom, 2 chars left/right
@@ -267,6 +410,7 @@ func (m *Model) updateViewSizes() {
 		m.LoginView,
 		m.AuditView,
 		m.PostInstallView,
+		m.OnboardingView,
 	}
 
 	for _, v := range views {
@@ -279,6 +423,16 @@ func (m *Model) updateViewSizes() {
 }
 
 func (m *Model) navigateTo(view ViewType) {
+	// Onboarding is only shown once; leaving it goes back to home
+	if m.currentView == ViewOnboarding {
+		m.currentView = view
+		m.viewStack = []ViewType{}
+		if view != ViewHome {
+			m.viewStack = append(m.viewStack, ViewHome)
+		}
+		return
+	}
+
 	// Push current view to stack
 	m.viewStack = append(m.viewStack, m.currentView)
 	m.currentView = view
@@ -335,6 +489,10 @@ func (m Model) initCurrentView() tea.Cmd {
 		if m.PostInstallView != nil {
 			return m.PostInstallView.Init()
 		}
+	case ViewOnboarding:
+		if m.OnboardingView != nil {
+			return m.OnboardingView.Init()
+		}
 	}
 	return nil
 }
@@ -379,6 +537,10 @@ func (m *Model) updateCurrentView(msg tea.Msg) tea.Cmd {
 		if m.PostInstallView != nil {
 			m.PostInstallView, cmd = m.PostInstallView.Update(msg)
 		}
+	case ViewOnboarding:
+		if m.OnboardingView != nil {
+			m.OnboardingView, cmd = m.OnboardingView.Update(msg)
+		}
 	}
 
 	return cmd
@@ -429,6 +591,10 @@ func (m *Model) View() string {
 		if m.PostInstallView != nil {
 			content = m.PostInstallView.View()
 		}
+	case ViewOnboarding:
+		if m.OnboardingView != nil {
+			content = m.OnboardingView.View()
+		}
 	default:
 		content = "Unknown view"
 	}
@@ -441,24 +607,72 @@ func (m *Model) View() string {
 	// Show help if enabled
 	if m.showHelp {
 		helpView := m.help.View(m.keys)
+		if km, ok := m.viewKeyMap(); ok {
+			helpView = m.help.FullHelpView(km.FullHelp())
+		}
 		paddedContent += "\n" + helpView
 	}
 
-	// Show error if any
-	if m.err != nil {
-		errorView := styles.ErrorStyle.Render("Error: " + m.err.Error())
-		paddedContent += "\n" + errorView
-	}
-
-	// Show status if any
+	// Show the status bar while there's a message
 	if m.status != "" {
-		statusView := styles.MutedStyle.Render(m.status)
-		paddedContent += "\n" + statusView
+		statusStyle := styles.MutedStyle
+		if m.statusIsErr {
+			statusStyle = styles.ErrorStyle
+		}
+		m.statusBar.SetLeft(statusStyle.Render(m.status))
+		paddedContent += "\n" + m.statusBar.View()
 	}
 
 	return paddedContent
 }
 
+// activeView returns the model for the current view, or nil if it isn't set
+func (m *Model) activeView() tea.Model {
+	return m.view(m.currentView)
+}
+
+// view returns the model for a view type, or nil if it isn't set
+func (m *Model) view(v ViewType) tea.Model {
+	switch v {
+	case ViewHome:
+		return m.HomeView
+	case ViewAppList:
+		return m.AppListView
+	case ViewAppDetail:
+		return m.AppDetailView
+	case ViewBrowse:
+		return m.BrowseView
+	case ViewPublish:
+		return m.PublishView
+	case ViewHelp:
+		return m.HelpView
+	case ViewLogin:
+		return m.LoginView
+	case ViewAudit:
+		return m.AuditView
+	case ViewPostInstall:
+		return m.PostInstallView
+	case ViewOnboarding:
+		return m.OnboardingView
+	}
+	return nil
+}
+
+// viewKeyMap returns the help key map for the current view, if the view
+// describes its own keys
+func (m *Model) viewKeyMap() (viewKeyMap, bool) {
+	helper, ok := m.activeView().(KeyHelper)
+	if !ok {
+		return viewKeyMap{}, false
+	}
+
+	global := []key.Binding{m.keys.Help}
+	if m.currentView == ViewHome || m.currentView == ViewOnboarding {
+		global = append(global, m.keys.Quit)
+	}
+	return viewKeyMap{view: helper.KeyHelp(), global: global}, true
+}
+
 // deleteApp removes an app from the index and filesystem
 func (m *Model) deleteApp(key string) tea.Cmd {
 	sessionDelete := m.SessionDelete
@@ -477,7 +691,7 @@ func (m *Model) deleteApp(key string) tea.Cmd {
 		// Remove directory if it exists
 		parts := strings.SplitN(key, "/", 2)
 		if len(parts) == 2 {
-			appPath := config.AppPath(parts[0], parts[1])
+			appPath := idx.Path(key)
 			if _, err := os.Stat(appPath); err == nil {
 				if err := os.RemoveAll(appPath); err != nil {
 					return AppRemovedMsg{Key: key, Err: err}
@@ -496,6 +710,8 @@ func (m *Model) deleteApp(key string) tea.Cmd {
 			_ = sessionDelete(key)
 		}
 
+		_ = events.Record(events.Remove, key)
+
 		return AppRemovedMsg{Key: key, Err: nil}
 	}
 }
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/components"
+)
+
+func TestIdleTimeout(t *testing.T) {
+	m := New(components.SpinnerDot)
+	m.SetIdleTimeout(time.Minute)
+	m.armIdle()
+	m.navigateTo(ViewBrowse)
+	m.navigateTo(ViewAppDetail)
+
+	// A keypress restarts the timer, so the earlier one no longer counts
+	stale := m.idleID
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(IdleMsg{ID: stale})
+	if m.currentView != ViewAppDetail {
+		t.Fatalf("stale IdleMsg moved to view %v", m.currentView)
+	}
+
+	m.Update(IdleMsg{ID: m.idleID})
+	if m.currentView != ViewHome || len(m.viewStack) != 0 {
+		t.Fatalf("idle detail view: got view %v, stack %v; want home with an empty stack", m.currentView, m.viewStack)
+	}
+
+	_, cmd := m.Update(IdleMsg{ID: m.idleID})
+	if cmd == nil {
+		t.Fatal("idle at home returned no command")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("idle at home did not quit")
+	}
+}
+
+// workView is a view with background work, for the idle timeout tests
+type workView struct {
+	busy bool
+	left bool
+}
+
+func (v *workView) Init() tea.Cmd                       { return nil }
+func (v *workView) Update(tea.Msg) (tea.Model, tea.Cmd) { return v, nil }
+func (v *workView) View() string                        { return "" }
+func (v *workView) Busy() bool                          { return v.busy }
+func (v *workView) Leave()                              { v.left = true }
+
+func TestIdleTimeoutWaitsForBusyView(t *testing.T) {
+	m := New(components.SpinnerDot)
+	m.SetIdleTimeout(time.Minute)
+	login := &workView{}
+	audit := &workView{busy: true}
+	m.SetLoginView(login)
+	m.SetAuditView(audit)
+	m.navigateTo(ViewLogin)
+	m.navigateTo(ViewAudit)
+
+	m.armIdle()
+	m.Update(IdleMsg{ID: m.idleID})
+	if m.currentView != ViewAudit {
+		t.Fatalf("idle while busy moved to view %v", m.currentView)
+	}
+	if login.left || audit.left {
+		t.Fatal("idle while busy left views")
+	}
+
+	audit.busy = false
+	m.Update(IdleMsg{ID: m.idleID})
+	if m.currentView != ViewHome {
+		t.Fatalf("idle after the work finished: got view %v, want home", m.currentView)
+	}
+	if !login.left || !audit.left {
+		t.Errorf("idle reset left login %v, audit %v; want both left", login.left, audit.left)
+	}
+}
+
+func TestRemappedGlobalKeys(t *testing.T) {
+	SetKeyBindings(map[string][]string{"quit": {"x"}, "help": {"H"}})
+	defer SetKeyBindings(nil)
+
+	m := New(components.SpinnerDot)
+	m.navigateTo(ViewHome)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	if !m.showHelp {
+		t.Error("remapped help key did not toggle help")
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); cmd != nil {
+		if _, ok := cmd().(tea.QuitMsg); ok {
+			t.Error("q quit after quit was remapped to x")
+		}
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if cmd == nil {
+		t.Fatal("remapped quit key returned no command")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("remapped quit key did not quit")
+	}
+}
@@ -0,0 +1,68 @@
+package components
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
+)
+
+// badgeColors are the backgrounds an initials badge can get, all dark
+// enough for white text
+var badgeColors = []lipgloss.Color{
+	styles.Primary,
+	"#8B5CF6", // Violet
+	"#EC4899", // Pink
+	"#0D9488", // Teal
+	"#D97706", // Dark amber
+	"#DC2626", // Dark red
+	"#16A34A", // Dark green
+	"#4F46E5", // Indigo
+}
+
+// Initials returns two uppercase letters for a person: the first letters of
+// the first and last words of name, or the first two letters of username
+// when name doesn't have two words
+func Initials(username, name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) >= 2 {
+		first, last := []rune(words[0]), []rune(words[len(words)-1])
+		return strings.ToUpper(string(first[0]) + string(last[0]))
+	}
+
+	var letters []rune
+	for _, r := range username + name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			letters = append(letters, r)
+		}
+	}
+	switch len(letters) {
+	case 0:
+		return "??"
+	case 1:
+		return strings.ToUpper(string(letters[0])) + " "
+	}
+	return strings.ToUpper(string(letters[:2]))
+}
+
+// BadgeColor picks a background for username's badge, the same one every
+// time so a creator is recognizable across apps
+func BadgeColor(username string) lipgloss.Color {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(username)))
+	return badgeColors[h.Sum32()%uint32(len(badgeColors))]
+}
+
+// InitialsBadge renders a creator's initials as a colored two-character
+// block, standing in for an avatar terminals can't show
+func InitialsBadge(username, name string) string {
+	return lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(BadgeColor(username)).
+		Render(Initials(username, name))
+}
@@ -0,0 +1,29 @@
+package components
+
+import "testing"
+
+func TestInitials(t *testing.T) {
+	tests := []struct {
+		username, name string
+		want           string
+	}{
+		{"ada", "Ada Lovelace", "AL"},
+		{"grace", "Grace Brewster Hopper", "GH"},
+		{"octocat", "", "OC"},
+		{"x", "", "X "},
+		{"octo-cat", "Octo", "OC"},
+		{"", "", "??"},
+		{"émile", "", "ÉM"},
+	}
+	for _, tt := range tests {
+		if got := Initials(tt.username, tt.name); got != tt.want {
+			t.Errorf("Initials(%q, %q) = %q; want %q", tt.username, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBadgeColor(t *testing.T) {
+	if BadgeColor("Octocat") != BadgeColor("octocat") {
+		t.Error("BadgeColor depends on username case")
+	}
+}
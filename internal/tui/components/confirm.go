@@ -0,0 +1,31 @@
+package components
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
+)
+
+// ConfirmButtons renders the Yes/No buttons of a destructive confirmation.
+// cursor 0 selects Yes, shown in the error color, and 1 selects No.
+func ConfirmButtons(cursor int) string {
+	yesStyle := lipgloss.NewStyle().Padding(0, 2)
+	noStyle := lipgloss.NewStyle().Padding(0, 2)
+
+	if cursor == 0 {
+		yesStyle = yesStyle.
+			Background(styles.Error).
+			Foreground(lipgloss.Color("#FFFFFF"))
+	} else {
+		yesStyle = yesStyle.Foreground(styles.Muted)
+	}
+
+	if cursor == 1 {
+		noStyle = noStyle.
+			Background(styles.Primary).
+			Foreground(lipgloss.Color("#FFFFFF"))
+	} else {
+		noStyle = noStyle.Foreground(styles.Muted)
+	}
+
+	return yesStyle.Render("Yes") + "  " + noStyle.Render("No")
+}
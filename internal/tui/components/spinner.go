@@ -1,6 +1,12 @@
 package components
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -14,27 +20,10 @@ type SpinnerModel struct {
 	style   lipgloss.Style
 }
 
-// NewSpinner creates a new spinner with the given message
-func NewSpinner(message string) SpinnerModel {
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(styles.Primary)
-
-	return SpinnerModel{
-		spinner: s,
-		message: message,
-		style:   lipgloss.NewStyle().Foreground(styles.Muted),
-	}
-}
-
 // NewSpinnerWithStyle creates a spinner with custom spinner style
 func NewSpinnerWithStyle(message string, spinnerStyle spinner.Spinner) SpinnerModel {
-	s := spinner.New()
-	s.Spinner = spinnerStyle
-	s.Style = lipgloss.NewStyle().Foreground(styles.Primary)
-
 	return SpinnerModel{
-		spinner: s,
+		spinner: NewSpinnerModel(spinnerStyle),
 		message: message,
 		style:   lipgloss.NewStyle().Foreground(styles.Muted),
 	}
@@ -79,3 +68,75 @@ var (
 	SpinnerMoon    = spinner.Moon
 	SpinnerMonkey  = spinner.Monkey
 )
+
+// SpinnerStyles maps the names accepted by the ui.spinner setting to styles.
+// "line" is plain ASCII, for terminals that can't draw the others.
+var SpinnerStyles = map[string]spinner.Spinner{
+	"dot":     SpinnerDot,
+	"line":    SpinnerLine,
+	"minidot": SpinnerMiniDot,
+	"jump":    SpinnerJump,
+	"pulse":   SpinnerPulse,
+	"points":  SpinnerPoints,
+	"globe":   SpinnerGlobe,
+	"moon":    SpinnerMoon,
+	"monkey":  SpinnerMonkey,
+}
+
+// SpinnerStyleNames returns the names in SpinnerStyles, sorted
+func SpinnerStyleNames() []string {
+	return slices.Sorted(maps.Keys(SpinnerStyles))
+}
+
+// SpinnerStyle returns the style called name, or Dot if name is empty or
+// unknown. ok is false only for unknown names.
+func SpinnerStyle(name string) (style spinner.Spinner, ok bool) {
+	if name == "" {
+		return SpinnerDot, true
+	}
+	style, ok = SpinnerStyles[strings.ToLower(name)]
+	if !ok {
+		return SpinnerDot, false
+	}
+	return style, true
+}
+
+// NewSpinnerModel returns a bubbles spinner in style, colored like the rest
+// of the TUI's spinners
+func NewSpinnerModel(style spinner.Spinner) spinner.Model {
+	s := spinner.New()
+	s.Spinner = style
+	s.Style = lipgloss.NewStyle().Foreground(styles.Primary)
+	return s
+}
+
+// Thresholds after which ElapsedNote reassures the user that a long
+// operation, such as Claude installing an app, hasn't hung
+const (
+	StillWorkingAfter = 30 * time.Second
+	TakingAWhileAfter = 2 * time.Minute
+)
+
+// ElapsedNote describes how long an operation has been running once it has
+// passed StillWorkingAfter, e.g. "still working... 45s", and returns "" before
+func ElapsedNote(elapsed time.Duration) string {
+	switch {
+	case elapsed >= TakingAWhileAfter:
+		return "this is taking a while, " + formatElapsed(elapsed)
+	case elapsed >= StillWorkingAfter:
+		return "still working... " + formatElapsed(elapsed)
+	}
+	return ""
+}
+
+// formatElapsed formats d to the second, e.g. "45s", "2m" or "2m5s"
+func formatElapsed(d time.Duration) string {
+	secs := int(d / time.Second)
+	switch {
+	case secs < 60:
+		return fmt.Sprintf("%ds", secs)
+	case secs%60 == 0:
+		return fmt.Sprintf("%dm", secs/60)
+	}
+	return fmt.Sprintf("%dm%ds", secs/60, secs%60)
+}
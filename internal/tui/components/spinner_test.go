@@ -0,0 +1,24 @@
+package components
+
+import (
+	"testing"
+	"time"
+)
+
+func TestElapsedNote(t *testing.T) {
+	tests := []struct {
+		elapsed time.Duration
+		want    string
+	}{
+		{10 * time.Second, ""},
+		{30 * time.Second, "still working... 30s"},
+		{90*time.Second + 400*time.Millisecond, "still working... 1m30s"},
+		{2 * time.Minute, "this is taking a while, 2m"},
+		{125 * time.Second, "this is taking a while, 2m5s"},
+	}
+	for _, tt := range tests {
+		if got := ElapsedNote(tt.elapsed); got != tt.want {
+			t.Errorf("ElapsedNote(%v) = %q; want %q", tt.elapsed, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package components
 
 import (
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
 )
@@ -15,11 +16,11 @@ type StatusBar struct {
 	spinner     SpinnerModel
 }
 
-// NewStatusBar creates a new status bar
-func NewStatusBar(width int) StatusBar {
+// NewStatusBar creates a new status bar whose spinner uses spinnerStyle
+func NewStatusBar(width int, spinnerStyle spinner.Spinner) StatusBar {
 	return StatusBar{
 		width:   width,
-		spinner: NewSpinner(""),
+		spinner: NewSpinnerWithStyle("", spinnerStyle),
 	}
 }
 
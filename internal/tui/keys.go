@@ -1,6 +1,13 @@
 package tui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 // KeyMap defines the key bindings for the TUI
 type KeyMap struct {
@@ -17,8 +24,149 @@ type KeyMap struct {
 	Filter   key.Binding
 }
 
-// DefaultKeyMap returns the default key bindings
+// KeyActions are the action names that can be remapped in the
+// keybindings section of the config
+var KeyActions = []string{"up", "down", "left", "right", "enter", "back", "quit", "help", "tab", "shiftTab", "filter"}
+
+// keyOverrides are the validated bindings from SetKeyBindings
+var keyOverrides map[string][]string
+
+// viewKeys are the keys views bind for actions of their own, by view, so
+// SetKeyBindings can stop a remapped global key from shadowing one
+var viewKeys = map[string][]key.Binding{}
+
+// ViewKey records b as one of view's own keys and returns it, for declaring
+// a view's bindings
+func ViewKey(view string, b key.Binding) key.Binding {
+	viewKeys[view] = append(viewKeys[view], b)
+	return b
+}
+
+// DefaultKeyMap returns the key bindings, with any overrides from
+// SetKeyBindings applied
 func DefaultKeyMap() KeyMap {
+	k := builtinKeyMap()
+	for action, keys := range keyOverrides {
+		b := k.action(action)
+		b.SetKeys(keys...)
+		b.SetHelp(strings.Join(keys, "/"), b.Help().Desc)
+	}
+	return k
+}
+
+// action returns the binding for an action name, or nil if it isn't one
+func (k *KeyMap) action(name string) *key.Binding {
+	switch name {
+	case "up":
+		return &k.Up
+	case "down":
+		return &k.Down
+	case "left":
+		return &k.Left
+	case "right":
+		return &k.Right
+	case "enter":
+		return &k.Enter
+	case "back":
+		return &k.Back
+	case "quit":
+		return &k.Quit
+	case "help":
+		return &k.Help
+	case "tab":
+		return &k.Tab
+	case "shiftTab":
+		return &k.ShiftTab
+	case "filter":
+		return &k.Filter
+	}
+	return nil
+}
+
+// SetKeyBindings validates remapped keys from the config and makes
+// DefaultKeyMap use them. Invalid entries, including keys a view already uses
+// for its own actions, keep their defaults and are described in the returned
+// warnings. It must be called before any view is created.
+func SetKeyBindings(bindings map[string][]string) []string {
+	var warnings []string
+	keyOverrides = map[string][]string{}
+
+	// Which action each key is bound to, to catch a key given to two actions
+	owner := map[string]string{}
+	for view, bindings := range viewKeys {
+		for _, b := range bindings {
+			for _, k := range b.Keys() {
+				owner[k] = fmt.Sprintf("%s in the %s view", b.Help().Desc, view)
+			}
+		}
+	}
+	defaults := builtinKeyMap()
+	for _, action := range KeyActions {
+		if _, ok := bindings[action]; ok {
+			continue
+		}
+		for _, k := range defaults.action(action).Keys() {
+			owner[k] = action
+		}
+	}
+
+	var unknown []string
+	for action := range bindings {
+		if defaults.action(action) == nil {
+			unknown = append(unknown, action)
+		}
+	}
+	sort.Strings(unknown)
+	for _, action := range unknown {
+		warnings = append(warnings, fmt.Sprintf("unknown key binding action %q (expected one of %s)", action, strings.Join(KeyActions, ", ")))
+	}
+
+	for _, action := range KeyActions {
+		keys, ok := bindings[action]
+		if !ok {
+			continue
+		}
+		if err := validateKeys(action, keys, owner); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%v; using the default keys for %s", err, action))
+			keys = defaults.action(action).Keys()
+			for _, k := range keys {
+				owner[k] = action
+			}
+			continue
+		}
+		// ctrl+c always quits, so a typo can't leave the TUI inescapable
+		if action == "quit" && !slices.Contains(keys, "ctrl+c") {
+			keys = append(slices.Clip(keys), "ctrl+c")
+		}
+		for _, k := range keys {
+			owner[k] = action
+		}
+		keyOverrides[action] = keys
+	}
+	return warnings
+}
+
+// validateKeys checks the keys bound to an action
+func validateKeys(action string, keys []string, owner map[string]string) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("no keys given for %s", action)
+	}
+	for _, k := range keys {
+		if strings.TrimSpace(k) == "" {
+			return fmt.Errorf("empty key for %s", action)
+		}
+		if other, ok := owner[k]; ok && other != action {
+			return fmt.Errorf("key %q for %s is already bound to %s", k, action, other)
+		}
+		if action != "quit" && k == "ctrl+c" {
+			return fmt.Errorf("ctrl+c is reserved for quit")
+		}
+	}
+	return nil
+}
+
+// builtinKeyMap returns the key bindings kiosk ships with
+func builtinKeyMap() KeyMap {
 	return KeyMap{
 		Up: key.NewBinding(
 			key.WithKeys("up", "k"),
@@ -80,3 +228,64 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		{k.Filter, k.Help, k.Quit},
 	}
 }
+
+// KeyHelper is implemented by views with keys of their own. The ? overlay
+// shows the active view's bindings instead of the generic ones.
+type KeyHelper interface {
+	KeyHelp() []key.Binding
+}
+
+// WithHelp returns a copy of b with its help description replaced, so a
+// view can say what a shared key does on its screen.
+func WithHelp(b key.Binding, desc string) key.Binding {
+	b.SetHelp(b.Help().Key, desc)
+	return b
+}
+
+// HelpLine renders bindings as a one-line hint, e.g. "enter select • q quit",
+// so fixed hints follow remapped keys
+func HelpLine(bindings ...key.Binding) string {
+	parts := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		parts = append(parts, b.Help().Key+" "+b.Help().Desc)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// Combined returns a help-only binding for bindings that share a
+// description, e.g. "↑/↓ navigate" for up and down. Each is shown by the
+// first key in its help, so remapped keys show as they are.
+func Combined(desc string, bindings ...key.Binding) key.Binding {
+	keys := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		k, _, _ := strings.Cut(b.Help().Key, "/")
+		if k == "" {
+			k = b.Help().Key
+		}
+		keys = append(keys, k)
+	}
+	return key.NewBinding(key.WithHelp(strings.Join(keys, "/"), desc))
+}
+
+// viewKeyMap is the help.KeyMap for the ? overlay: the active view's
+// bindings followed by the global ones.
+type viewKeyMap struct {
+	view   []key.Binding
+	global []key.Binding
+}
+
+// helpColumnSize is how many bindings go in each column of the full help
+const helpColumnSize = 4
+
+func (k viewKeyMap) ShortHelp() []key.Binding {
+	return append(append([]key.Binding{}, k.view...), k.global...)
+}
+
+func (k viewKeyMap) FullHelp() [][]key.Binding {
+	var columns [][]key.Binding
+	for i := 0; i < len(k.view); i += helpColumnSize {
+		end := min(i+helpColumnSize, len(k.view))
+		columns = append(columns, k.view[i:end])
+	}
+	return append(columns, k.global)
+}
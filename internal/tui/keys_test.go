@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+func TestSetKeyBindings(t *testing.T) {
+	defer SetKeyBindings(nil)
+
+	warnings := SetKeyBindings(map[string][]string{
+		"back":   {"esc", "b"},
+		"quit":   {"x"},
+		"filter": {"k"},      // taken by up
+		"help":   {},         // no keys
+		"jump":   {"g"},      // not an action
+		"tab":    {"ctrl+c"}, // reserved for quit
+	})
+	if len(warnings) != 4 {
+		t.Errorf("got %d warnings, want 4: %q", len(warnings), warnings)
+	}
+
+	k := DefaultKeyMap()
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{name: "remapped", got: k.Back.Keys(), want: []string{"esc", "b"}},
+		{name: "quit keeps ctrl+c", got: k.Quit.Keys(), want: []string{"x", "ctrl+c"}},
+		{name: "conflict falls back", got: k.Filter.Keys(), want: []string{"/"}},
+		{name: "empty falls back", got: k.Help.Keys(), want: []string{"?"}},
+		{name: "reserved falls back", got: k.Tab.Keys(), want: []string{"tab"}},
+		{name: "untouched", got: k.Up.Keys(), want: []string{"up", "k"}},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s: keys = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if got := k.Back.Help().Key; got != "esc/b" {
+		t.Errorf("Back help key = %q, want %q", got, "esc/b")
+	}
+}
+
+func TestSetKeyBindingsViewKeys(t *testing.T) {
+	ViewKey("test", key.NewBinding(key.WithKeys("z"), key.WithHelp("z", "zap")))
+	defer SetKeyBindings(nil)
+
+	warnings := SetKeyBindings(map[string][]string{"up": {"z"}})
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "zap in the test view") {
+		t.Errorf("warnings = %q, want one about the test view's z key", warnings)
+	}
+	if got := DefaultKeyMap().Up.Keys(); !reflect.DeepEqual(got, []string{"up", "k"}) {
+		t.Errorf("Up keys = %q, want the defaults", got)
+	}
+}
+
+func TestCombined(t *testing.T) {
+	SetKeyBindings(map[string][]string{"down": {"ctrl+n", "down"}})
+	defer SetKeyBindings(nil)
+
+	k := DefaultKeyMap()
+	if got := Combined("navigate", k.Up, k.Down).Help(); got.Key != "↑/ctrl+n" || got.Desc != "navigate" {
+		t.Errorf("Combined() help = %+v, want ↑/ctrl+n navigate", got)
+	}
+	if got := Combined("filter", k.Filter).Help().Key; got != "/" {
+		t.Errorf("Combined() of / = %q, want /", got)
+	}
+}
@@ -3,6 +3,7 @@ package tui
 import (
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/reflective-technologies/kiosk-cli/internal/api"
 	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
 	"github.com/reflective-technologies/kiosk-cli/internal/auth"
@@ -26,6 +27,12 @@ type AppsLoadedMsg struct {
 	Err   error
 }
 
+// AppsValidatedMsg is sent once each app's directory has been checked, so
+// the list can render before the filesystem is touched
+type AppsValidatedMsg struct {
+	Exists map[string]bool // app key -> directory exists
+}
+
 // AppSelectedMsg is sent when a user selects an app
 type AppSelectedMsg struct {
 	Key   string
@@ -53,6 +60,7 @@ type LoginStartedMsg struct {
 	UserCode        string
 	VerificationURI string
 	Interval        int // Polling interval in seconds (per RFC 8628)
+	ExpiresIn       int // Seconds until the device code expires
 }
 
 // LoginCompleteMsg is sent when login completes
@@ -86,6 +94,18 @@ type CloneCompleteMsg struct {
 	Err  error
 }
 
+// InstallStartedMsg is sent when an install starts streaming into the
+// post-install view. Events carries its InstallLogMsg, CloneCompleteMsg, and
+// AppInstalledMsg in order, and is closed after the last one.
+type InstallStartedMsg struct {
+	Events <-chan tea.Msg
+}
+
+// InstallLogMsg carries a line of output from the clone or install step
+type InstallLogMsg struct {
+	Line string
+}
+
 // AuditStartedMsg is sent when audit begins
 type AuditStartedMsg struct{}
 
@@ -109,6 +129,7 @@ type BrowseAppsPageLoadedMsg struct {
 	Apps       []api.App
 	NextCursor *string // cursor for next page, nil if no more pages
 	Err        error
+	Cursor     string // cursor the page was requested with
 	Generation uint64 // generation ID to detect stale messages after view reset
 }
 
@@ -169,11 +190,20 @@ type SuccessMsg struct {
 	Message string
 }
 
-// StatusMsg is a transient status message
+// StatusMsg is a transient status message. A zero Timeout uses the default.
 type StatusMsg struct {
 	Message string
 	Timeout time.Duration
 }
 
-// ClearStatusMsg clears the current status message
-type ClearStatusMsg struct{}
+// IdleMsg fires when the TUI has had no input for the idle timeout, unless
+// input since has restarted the timer
+type IdleMsg struct {
+	ID uint64
+}
+
+// ClearStatusMsg clears the status bar, unless a newer message has replaced
+// the one it was scheduled for
+type ClearStatusMsg struct {
+	ID uint64
+}
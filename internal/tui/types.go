@@ -14,6 +14,7 @@ const (
 	ViewAudit
 	ViewPostInstall
 	ViewSettings
+	ViewOnboarding
 )
 
 // String returns the string representation of the view type
@@ -39,6 +40,8 @@ func (v ViewType) String() string {
 		return "Post Install"
 	case ViewSettings:
 		return "Settings"
+	case ViewOnboarding:
+		return "Welcome"
 	default:
 		return "Unknown"
 	}
@@ -1,17 +1,30 @@
 package views
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/reflective-technologies/kiosk-cli/internal/api"
 	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
+	"github.com/reflective-technologies/kiosk-cli/internal/auth"
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	"github.com/reflective-technologies/kiosk-cli/internal/git"
 	"github.com/reflective-technologies/kiosk-cli/internal/giturl"
+	"github.com/reflective-technologies/kiosk-cli/internal/kioskmd"
 	"github.com/reflective-technologies/kiosk-cli/internal/sessions"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/components"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
 )
 
@@ -20,6 +33,7 @@ type AppDetailModel struct {
 	width  int
 	height int
 	keys   tui.KeyMap
+	git    *git.Git // checks for local work before a delete
 
 	// App info
 	app         *api.App
@@ -29,12 +43,98 @@ type AppDetailModel struct {
 
 	// Button selection (0 = Run, 1 = Delete for installed; 0 = Install for browse)
 	cursor int
+
+	// Description, scrollable when it doesn't fit
+	desc viewport.Model
+
+	// Delete confirmation state
+	confirmingDelete bool
+	confirmCursor    int   // 0 = Yes, 1 = No
+	diskUsage        int64 // -1 while calculating
+	diskUsageErr     error
+	unsaved          []string // local git work deleting would lose
+
+	// Report state
+	reporting   bool
+	reportInput textinput.Model
+
+	// Install prompt preview state, also used to read the app's KIOSK.md
+	previewing      bool
+	previewManifest bool // the preview shows KIOSK.md rather than the install prompt
+	previewLoading  bool
+	previewErr      error
+	preview         viewport.Model
+	spinner         spinner.Model
+}
+
+// appDetailChromeRows is the height of everything around the description:
+// title, subheader and spacing above; buttons and help below
+const appDetailChromeRows = 7
+
+// appDetailConfirmRows is how many more rows the delete confirmation takes
+// than the action buttons
+const appDetailConfirmRows = 4
+
+var appDetailReportKey = tui.ViewKey("app detail", key.NewBinding(
+	key.WithKeys("r"),
+	key.WithHelp("r", "report app"),
+))
+
+var appDetailPreviewKey = tui.ViewKey("app detail", key.NewBinding(
+	key.WithKeys("p"),
+	key.WithHelp("p", "preview install prompt"),
+))
+
+var appDetailManifestKey = tui.ViewKey("app detail", key.NewBinding(
+	key.WithKeys("m"),
+	key.WithHelp("m", "read KIOSK.md"),
+))
+
+// appDetailManifestMsg carries the fetched KIOSK.md for an app; content is
+// empty if the app has none
+type appDetailManifestMsg struct {
+	appID   string
+	content string
+	err     error
 }
 
-// NewAppDetailModel creates a new app detail model
-func NewAppDetailModel() AppDetailModel {
+// appDetailPromptMsg carries the fetched install prompt for an app
+type appDetailPromptMsg struct {
+	appID  string
+	prompt string
+	err    error
+}
+
+// appDetailReportedMsg is sent when a report has been submitted
+type appDetailReportedMsg struct {
+	err error
+}
+
+// appDetailDiskUsageMsg reports the size of an app directory pending
+// deletion and any local git work in it
+type appDetailDiskUsageMsg struct {
+	key     string
+	size    int64
+	err     error
+	unsaved []string
+}
+
+// NewAppDetailModel creates a new app detail model. g runs the git commands
+// that look for local work before an app is deleted.
+func NewAppDetailModel(spinnerStyle spinner.Spinner, g *git.Git) AppDetailModel {
+	ti := textinput.New()
+	ti.Placeholder = "What's wrong with this app?"
+	ti.CharLimit = 500
+
+	s := components.NewSpinnerModel(spinnerStyle)
+
 	return AppDetailModel{
-		keys: tui.DefaultKeyMap(),
+		keys:        tui.DefaultKeyMap(),
+		git:         g,
+		reportInput: ti,
+		desc:        viewport.New(0, 0),
+		preview:     viewport.New(0, 0),
+		spinner:     s,
 	}
 }
 
@@ -43,61 +143,78 @@ func (m *AppDetailModel) SetApp(app *api.App, isInstalled bool, appKey string, h
 	m.app = app
 	m.appKey = appKey
 	m.cursor = 0
-
-	// Check if the app is installed by looking at the app index
-	if isInstalled {
-		m.isInstalled = true
-	} else {
-		m.isInstalled = m.checkIfInstalled(app)
+	m.confirmingDelete = false
+	m.reporting = false
+	m.previewing = false
+	m.layoutDescription()
+	m.desc.GotoTop()
+
+	// Check if the app is installed by looking at the app index. If it is,
+	// use its index key so run and delete act on the installed copy.
+	m.isInstalled = isInstalled
+	if !isInstalled && app != nil {
+		if idx, err := appindex.Load(); err == nil {
+			if key := installedKey(idx, *app); key != "" {
+				m.isInstalled = true
+				m.appKey = key
+			}
+		}
 	}
 
 	if hasSession {
 		m.hasSession = true
 	} else {
-		m.hasSession = m.checkHasSession(app, appKey)
+		m.hasSession = m.checkHasSession(app, m.appKey)
 	}
 }
 
-// checkIfInstalled checks if the app is in the local app index
-func (m *AppDetailModel) checkIfInstalled(app *api.App) bool {
-	if app == nil {
-		return false
-	}
+// SetSize updates the view dimensions
+func (m *AppDetailModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
 
-	idx, err := appindex.Load()
-	if err != nil {
-		return false
-	}
+	// Title, subheader, and heading above; help below
+	m.preview.Width = width
+	m.preview.Height = max(height-7, 3)
+
+	m.layoutDescription()
+}
 
-	// Check by app ID
-	if idx.Get(app.ID) != nil {
-		return true
+// layoutDescription wraps the description to the view width and sizes its
+// viewport to the content, up to the height left over by the rest of the view
+func (m *AppDetailModel) layoutDescription() {
+	if m.app == nil || m.app.Description == "" {
+		m.desc.SetContent("")
+		m.desc.Height = 0
+		return
 	}
 
-	// Also check by extracting repo name from git URL if available
-	if app.GitUrl != "" {
-		// Try to extract owner/repo from git URL
-		// e.g., https://github.com/owner/repo -> owner/repo
-		gitUrl := app.GitUrl
-		gitUrl = strings.TrimSuffix(gitUrl, ".git")
-		if strings.Contains(gitUrl, "github.com/") {
-			parts := strings.Split(gitUrl, "github.com/")
-			if len(parts) == 2 {
-				repoPath := parts[1]
-				if idx.Get(repoPath) != nil {
-					return true
-				}
-			}
-		}
+	contentWidth := m.width
+	if contentWidth <= 0 {
+		contentWidth = 80
+	}
+	wrapped := lipgloss.NewStyle().
+		Foreground(styles.Foreground).
+		Width(max(contentWidth-3, 10)). // account for indent
+		Render(m.app.Description)
+	lines := strings.Split(wrapped, "\n")
+	for i := range lines {
+		lines[i] = "  " + lines[i]
 	}
 
-	return false
+	m.desc.Width = contentWidth
+	m.desc.SetContent(strings.Join(lines, "\n"))
+	available := m.height - appDetailChromeRows
+	if m.height <= 0 {
+		available = len(lines)
+	}
+	m.desc.Height = min(len(lines), max(available, 3))
+	m.desc.SetYOffset(m.desc.YOffset) // keep the offset in range
 }
 
-// SetSize updates the view dimensions
-func (m *AppDetailModel) SetSize(width, height int) {
-	m.width = width
-	m.height = height
+// descScrollable reports whether the description is taller than its viewport
+func (m *AppDetailModel) descScrollable() bool {
+	return m.desc.TotalLineCount() > m.desc.Height
 }
 
 // Init initializes the app detail model
@@ -109,14 +226,34 @@ func (m *AppDetailModel) Init() tea.Cmd {
 func (m *AppDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.confirmingDelete {
+			return m, m.updateConfirmDelete(msg)
+		}
+		if m.reporting {
+			return m, m.updateReport(msg)
+		}
+		if m.previewing {
+			if key.Matches(msg, m.keys.Back) {
+				m.previewing = false
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.preview, cmd = m.preview.Update(msg)
+			return m, cmd
+		}
+
 		switch {
 		case key.Matches(msg, m.keys.Back):
 			return m, func() tea.Msg { return tui.GoBackMsg{} }
-		case key.Matches(msg, m.keys.Up), msg.String() == "left":
+		case key.Matches(msg, m.keys.Up) && m.descScrollable():
+			m.desc.LineUp(1)
+		case key.Matches(msg, m.keys.Down) && m.descScrollable():
+			m.desc.LineDown(1)
+		case key.Matches(msg, m.keys.Up, m.keys.Left):
 			if m.cursor > 0 {
 				m.cursor--
 			}
-		case key.Matches(msg, m.keys.Down), msg.String() == "right":
+		case key.Matches(msg, m.keys.Down, m.keys.Right):
 			maxCursor := 0
 			if m.isInstalled {
 				maxCursor = 1 // Run and Delete
@@ -126,15 +263,225 @@ func (m *AppDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case key.Matches(msg, m.keys.Enter):
 			return m, m.handleAction()
+		case key.Matches(msg, appDetailReportKey):
+			if m.app != nil && m.app.ID != "" {
+				m.reporting = true
+				m.reportInput.Reset()
+				return m, m.reportInput.Focus()
+			}
+		case key.Matches(msg, appDetailPreviewKey):
+			if m.app != nil && m.app.ID != "" {
+				m.previewing = true
+				m.previewManifest = false
+				m.previewLoading = true
+				m.previewErr = nil
+				return m, tea.Batch(m.spinner.Tick, fetchInstallPrompt(m.app.ID, m.appKey))
+			}
+		case key.Matches(msg, appDetailManifestKey):
+			if m.app != nil && m.app.ID != "" {
+				m.previewing = true
+				m.previewManifest = true
+				m.previewErr = nil
+				if m.app.KioskMd != "" {
+					m.previewLoading = false
+					m.setManifest(m.app.KioskMd)
+					return m, nil
+				}
+				m.previewLoading = true
+				return m, tea.Batch(m.spinner.Tick, fetchManifest(m.app.ID, m.appKey, m.isInstalled))
+			}
+		}
+
+	case spinner.TickMsg:
+		if m.previewing && m.previewLoading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+
+	case appDetailPromptMsg:
+		if !m.previewing || m.previewManifest || m.app == nil || msg.appID != m.app.ID {
+			return m, nil
+		}
+		m.previewLoading = false
+		m.previewErr = msg.err
+		if msg.err == nil {
+			m.preview.SetContent(m.renderPrompt(msg.prompt))
+			m.preview.GotoTop()
+		}
+
+	case appDetailManifestMsg:
+		if !m.previewing || !m.previewManifest || m.app == nil || msg.appID != m.app.ID {
+			return m, nil
+		}
+		m.previewLoading = false
+		m.previewErr = msg.err
+		if msg.err == nil {
+			m.app.KioskMd = msg.content // no need to fetch it again
+			m.setManifest(msg.content)
 		}
 
+	case appDetailReportedMsg:
+		switch {
+		case errors.Is(msg.err, api.ErrReportingUnsupported):
+			return m, statusCmd("Reporting apps isn't supported by this server yet")
+		case msg.err != nil:
+			return m, func() tea.Msg { return tui.ErrorMsg{Err: msg.err} }
+		}
+		return m, statusCmd("Thanks, your report was sent")
+
 	case tui.ShowAppDetailMsg:
 		m.SetApp(msg.App, msg.IsInstalled, msg.AppKey, msg.HasSession)
+
+	case appDetailDiskUsageMsg:
+		if msg.key == m.appKey {
+			m.diskUsage = msg.size
+			m.diskUsageErr = msg.err
+			m.unsaved = msg.unsaved
+		}
 	}
 
 	return m, nil
 }
 
+func (m *AppDetailModel) updateConfirmDelete(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.confirmingDelete = false
+	case key.Matches(msg, m.keys.Left):
+		if m.confirmCursor > 0 {
+			m.confirmCursor--
+		}
+	case key.Matches(msg, m.keys.Right):
+		if m.confirmCursor < 1 {
+			m.confirmCursor++
+		}
+	case key.Matches(msg, m.keys.Enter):
+		m.confirmingDelete = false
+		if m.confirmCursor == 0 {
+			appKey := m.appKey
+			return func() tea.Msg {
+				return tui.DeleteAppMsg{
+					AppKey: appKey,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (m *AppDetailModel) updateReport(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Back) && msg.String() != "backspace": // backspace edits the reason
+		m.reporting = false
+		m.reportInput.Blur()
+		return nil
+	case key.Matches(msg, m.keys.Enter):
+		reason := strings.TrimSpace(m.reportInput.Value())
+		if reason == "" {
+			return nil
+		}
+		m.reporting = false
+		m.reportInput.Blur()
+		return submitReport(m.app.ID, m.appKey, reason)
+	}
+
+	var cmd tea.Cmd
+	m.reportInput, cmd = m.reportInput.Update(msg)
+	return cmd
+}
+
+// submitReport sends a report for the app with the user's credentials, to
+// the API it was installed from if it's installed as appKey
+func submitReport(appID, appKey, reason string) tea.Cmd {
+	return func() tea.Msg {
+		if !auth.IsLoggedIn() {
+			return appDetailReportedMsg{err: fmt.Errorf("log in to report apps")}
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			return appDetailReportedMsg{err: err}
+		}
+		apiURL, _ := appindex.Origin(cfg, appKey)
+		client := api.NewOriginClient(apiURL, cfg.APIUrl)
+		return appDetailReportedMsg{err: client.ReportApp(appID, reason)}
+	}
+}
+
+// fetchInstallPrompt fetches the instructions Claude would be given to
+// install the app, from where it was installed from if it's installed as
+// appKey
+func fetchInstallPrompt(appID, appKey string) tea.Cmd {
+	return func() tea.Msg {
+		cfg, err := config.Load()
+		if err != nil {
+			return appDetailPromptMsg{appID: appID, err: err}
+		}
+		apiURL, registry := appindex.Origin(cfg, appKey)
+		client := api.NewOriginClient(apiURL, cfg.APIUrl).WithRegistry(registry)
+		prompt, err := client.GetInstallPrompt(appID)
+		return appDetailPromptMsg{appID: appID, prompt: prompt, err: err}
+	}
+}
+
+// fetchManifest fetches the app's KIOSK.md, reading it from the installed
+// copy if there is one and asking the API otherwise
+func fetchManifest(appID, appKey string, installed bool) tea.Cmd {
+	return func() tea.Msg {
+		if installed {
+			if path := kioskmd.Find(appindex.Path(appKey)); path != "" {
+				data, err := os.ReadFile(path)
+				return appDetailManifestMsg{appID: appID, content: string(data), err: err}
+			}
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			return appDetailManifestMsg{appID: appID, err: err}
+		}
+		apiURL, registry := appindex.Origin(cfg, appKey)
+		client := api.NewOriginClient(apiURL, cfg.APIUrl).WithRegistry(registry)
+		app, err := client.GetApp(appID)
+		if err != nil {
+			return appDetailManifestMsg{appID: appID, err: err}
+		}
+		return appDetailManifestMsg{appID: appID, content: app.KioskMd}
+	}
+}
+
+// setManifest shows content, the app's KIOSK.md, in the preview
+func (m *AppDetailModel) setManifest(content string) {
+	if strings.TrimSpace(content) == "" {
+		m.preview.SetContent("  " + styles.MutedStyle.Render("This app has no KIOSK.md."))
+	} else {
+		m.preview.SetContent(m.renderPrompt(content))
+	}
+	m.preview.GotoTop()
+}
+
+// renderPrompt renders an install prompt or KIOSK.md as markdown, falling
+// back to the raw text
+func (m *AppDetailModel) renderPrompt(prompt string) string {
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithAutoStyle(),
+		glamour.WithWordWrap(max(m.width-4, 20)),
+	)
+	if err != nil {
+		return prompt
+	}
+	rendered, err := renderer.Render(prompt)
+	if err != nil {
+		return prompt
+	}
+	return rendered
+}
+
+// statusCmd shows a transient status message
+func statusCmd(message string) tea.Cmd {
+	return func() tea.Msg {
+		return tui.StatusMsg{Message: message, Timeout: 3 * time.Second}
+	}
+}
+
 func (m *AppDetailModel) handleAction() tea.Cmd {
 	if m.app == nil {
 		return nil
@@ -150,11 +497,21 @@ func (m *AppDetailModel) handleAction() tea.Cmd {
 				}
 			}
 		} else {
-			// Delete
+			// Delete, after confirmation
+			m.confirmingDelete = true
+			m.confirmCursor = 1 // Default to No for safety
+			m.diskUsage = -1
+			m.diskUsageErr = nil
+			m.unsaved = nil
+			appKey := m.appKey
+			g := m.git
 			return func() tea.Msg {
-				return tui.DeleteAppMsg{
-					AppKey: m.appKey,
+				size, err := appindex.DiskUsage(appKey)
+				var unsaved []string
+				if git.Available() {
+					unsaved = g.UnsavedWork(context.Background(), appindex.Path(appKey))
 				}
+				return appDetailDiskUsageMsg{key: appKey, size: size, err: err, unsaved: unsaved}
 			}
 		}
 	} else {
@@ -168,6 +525,54 @@ func (m *AppDetailModel) handleAction() tea.Cmd {
 	}
 }
 
+// KeyHelp returns the keys shown in the help overlay
+func (m *AppDetailModel) KeyHelp() []key.Binding {
+	if m.confirmingDelete {
+		return []key.Binding{
+			m.keys.Left,
+			m.keys.Right,
+			tui.WithHelp(m.keys.Enter, "confirm"),
+			tui.WithHelp(m.keys.Back, "cancel"),
+		}
+	}
+	if m.reporting {
+		return []key.Binding{
+			tui.WithHelp(m.keys.Enter, "send report"),
+			tui.WithHelp(m.keys.Back, "cancel"),
+		}
+	}
+	if m.previewing {
+		return []key.Binding{
+			tui.WithHelp(m.preview.KeyMap.Up, "scroll up"),
+			tui.WithHelp(m.preview.KeyMap.Down, "scroll down"),
+			m.preview.KeyMap.PageDown,
+			tui.WithHelp(m.keys.Back, "close"),
+		}
+	}
+	if m.descScrollable() {
+		return []key.Binding{
+			tui.WithHelp(m.keys.Up, "scroll up"),
+			tui.WithHelp(m.keys.Down, "scroll down"),
+			tui.WithHelp(m.keys.Left, "previous action"),
+			tui.WithHelp(m.keys.Right, "next action"),
+			tui.WithHelp(m.keys.Enter, "run action"),
+			appDetailPreviewKey,
+			appDetailManifestKey,
+			appDetailReportKey,
+			m.keys.Back,
+		}
+	}
+	return []key.Binding{
+		tui.WithHelp(m.keys.Left, "previous action"),
+		tui.WithHelp(m.keys.Right, "next action"),
+		tui.WithHelp(m.keys.Enter, "run action"),
+		appDetailPreviewKey,
+		appDetailManifestKey,
+		appDetailReportKey,
+		m.keys.Back,
+	}
+}
+
 // View renders the app detail view
 func (m *AppDetailModel) View() string {
 	if m.app == nil {
@@ -192,15 +597,22 @@ func (m *AppDetailModel) View() string {
 
 	// Author/Creator as subheader with install count
 	var subheaderParts []string
-	if m.app.Creator != nil && m.app.Creator.Username != "" {
-		subheaderParts = append(subheaderParts, fmt.Sprintf("by %s", m.app.Creator.Username))
+	if username := creatorName(*m.app); username != "" {
+		creator := styles.MutedStyle.Render("by ") + creatorLabel(*m.app)
+		if name := strings.TrimSpace(m.app.Creator.Name); name != "" && !strings.EqualFold(name, username) {
+			creator += styles.MutedStyle.Render(" (@" + username + ")")
+		}
+		subheaderParts = append(subheaderParts, creator)
 	}
 	if m.app.InstallCount > 0 {
 		installText := "install"
 		if m.app.InstallCount != 1 {
 			installText = "installs"
 		}
-		subheaderParts = append(subheaderParts, fmt.Sprintf("%d %s", m.app.InstallCount, installText))
+		subheaderParts = append(subheaderParts, fmt.Sprintf("%s %s", formatCount(m.app.InstallCount), installText))
+	}
+	if updated, ok := m.app.ParsedUpdatedAt(); ok {
+		subheaderParts = append(subheaderParts, styles.MutedStyle.Render("updated "+relativeTime(updated)))
 	}
 	if m.isInstalled {
 		subheaderParts = append(subheaderParts, styles.SuccessStyle.Render("Installed"))
@@ -217,14 +629,40 @@ func (m *AppDetailModel) View() string {
 
 	b.WriteString("\n")
 
+	if m.previewing {
+		m.renderPreview(&b, indent, contentWidth)
+		return b.String()
+	}
+
 	// Description
 	if m.app.Description != "" {
-		descStyle := lipgloss.NewStyle().
-			Foreground(styles.Foreground).
-			MaxWidth(contentWidth - 3) // account for indent
+		desc := m.desc
+		if m.confirmingDelete && m.height > 0 {
+			// Make room for the confirmation below
+			desc.Height = min(desc.Height, max(m.height-appDetailChromeRows-appDetailConfirmRows-len(m.unsaved), 1))
+		}
+		b.WriteString(desc.View())
+		b.WriteString("\n\n")
+	}
+
+	if m.confirmingDelete {
+		m.renderConfirmDelete(&b, indent, contentWidth)
+		return b.String()
+	}
+
+	if m.reporting {
 		b.WriteString(indent)
-		b.WriteString(descStyle.Render(m.app.Description))
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Report this app"))
+		b.WriteString("\n")
+		b.WriteString(indent)
+		b.WriteString(m.reportInput.View())
 		b.WriteString("\n\n")
+		b.WriteString(indent)
+		b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render(tui.HelpLine(
+			tui.WithHelp(m.keys.Enter, "send"),
+			tui.WithHelp(m.keys.Back, "cancel"),
+		)))
+		return b.String()
 	}
 
 	// Action buttons
@@ -234,11 +672,116 @@ func (m *AppDetailModel) View() string {
 
 	// Help
 	b.WriteString(indent)
-	b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render("←/→ select • enter confirm • esc go back"))
+	help := tui.HelpLine(
+		tui.Combined("select", m.keys.Left, m.keys.Right),
+		tui.WithHelp(m.keys.Enter, "confirm"),
+		tui.WithHelp(appDetailPreviewKey, "preview prompt"),
+		tui.WithHelp(appDetailManifestKey, "KIOSK.md"),
+		tui.WithHelp(appDetailReportKey, "report"),
+		tui.WithHelp(m.keys.Back, "go back"),
+	)
+	if m.descScrollable() {
+		scroll := tui.Combined("scroll", m.keys.Up, m.keys.Down)
+		help = fmt.Sprintf("%s • %s • %d%%", tui.HelpLine(scroll), help, int(m.desc.ScrollPercent()*100))
+	}
+	b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render(help))
 
 	return b.String()
 }
 
+// renderPreview shows the app's install prompt so the user can review what
+// Claude will be asked to do before installing, or the app's KIOSK.md
+func (m *AppDetailModel) renderPreview(b *strings.Builder, indent string, contentWidth int) {
+	what := "install prompt"
+	b.WriteString(indent)
+	if m.previewManifest {
+		what = "KIOSK.md"
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("KIOSK.md"))
+		b.WriteString(styles.MutedStyle.Render("  what the app does and how it's set up"))
+	} else {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Install prompt"))
+		b.WriteString(styles.MutedStyle.Render("  what Claude will be asked to do"))
+	}
+	b.WriteString("\n")
+
+	switch {
+	case m.previewLoading:
+		b.WriteString(indent)
+		b.WriteString(m.spinner.View())
+		b.WriteString(" ")
+		b.WriteString(styles.MutedStyle.Render("Fetching " + what + "..."))
+		b.WriteString("\n\n")
+		b.WriteString(indent)
+		b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render(tui.HelpLine(tui.WithHelp(m.keys.Back, "close"))))
+	case m.previewErr != nil:
+		b.WriteString(indent)
+		b.WriteString(styles.ErrorStyle.Render("✗ Couldn't fetch the " + what))
+		b.WriteString("\n")
+		b.WriteString(indent)
+		b.WriteString(styles.MutedStyle.Copy().MaxWidth(contentWidth - 3).Render(m.previewErr.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(indent)
+		b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render(tui.HelpLine(tui.WithHelp(m.keys.Back, "close"))))
+	default:
+		b.WriteString(m.preview.View())
+		b.WriteString("\n")
+		scrollPercent := int(m.preview.ScrollPercent() * 100)
+		b.WriteString(indent)
+		b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render(fmt.Sprintf("%s • %d%%", tui.HelpLine(
+			tui.Combined("scroll", m.preview.KeyMap.Up, m.preview.KeyMap.Down),
+			tui.WithHelp(m.keys.Back, "close"),
+		), scrollPercent)))
+	}
+}
+
+func (m *AppDetailModel) renderConfirmDelete(b *strings.Builder, indent string, contentWidth int) {
+	b.WriteString(indent)
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Delete this app?"))
+	b.WriteString("\n")
+
+	// Disk usage
+	b.WriteString(indent)
+	switch {
+	case m.diskUsageErr != nil:
+		b.WriteString(styles.MutedStyle.Render("Disk usage unknown"))
+	case m.diskUsage < 0:
+		b.WriteString(styles.MutedStyle.Render("Calculating disk usage..."))
+	default:
+		b.WriteString("Frees " + lipgloss.NewStyle().Bold(true).Render(appindex.FormatSize(m.diskUsage)))
+	}
+	b.WriteString("\n")
+
+	// Session state
+	b.WriteString(indent)
+	if m.hasSession {
+		b.WriteString(styles.WarningStyle.Render("Has a saved session that will be discarded"))
+	} else {
+		b.WriteString(styles.MutedStyle.Render("No saved session"))
+	}
+	b.WriteString("\n")
+
+	// Local git work that isn't on the remote
+	for _, w := range m.unsaved {
+		b.WriteString(indent)
+		b.WriteString(styles.WarningStyle.Render("Will lose " + w))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	// Yes/No buttons
+	b.WriteString(indent)
+	b.WriteString(components.ConfirmButtons(m.confirmCursor))
+	b.WriteString("\n\n")
+
+	// Help
+	b.WriteString(indent)
+	b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render(tui.HelpLine(
+		tui.Combined("select", m.keys.Left, m.keys.Right),
+		tui.WithHelp(m.keys.Enter, "confirm"),
+		tui.WithHelp(m.keys.Back, "cancel"),
+	)))
+}
+
 func (m *AppDetailModel) renderButtons() string {
 	if m.isInstalled {
 		// Run and Delete buttons
@@ -2,7 +2,6 @@ package views
 
 import (
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -52,6 +51,7 @@ type AppListModel struct {
 	keys     tui.KeyMap
 	index    *appindex.Index
 	selected *appItem
+	exists   map[string]bool // nil until the filesystem has been checked
 	loading  bool
 	err      error
 }
@@ -143,6 +143,12 @@ func (m *AppListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.err = nil
 		m.index = msg.Index
+		m.exists = nil
+		m.updateListItems()
+		cmds = append(cmds, validateApps(msg.Index))
+
+	case tui.AppsValidatedMsg:
+		m.exists = msg.Exists
 		m.updateListItems()
 	}
 
@@ -160,10 +166,6 @@ func (m *AppListModel) updateListItems() {
 	}
 
 	keys := m.index.List()
-	sort.Strings(keys)
-
-	// Validate filesystem
-	exists := m.index.ValidateFilesystem()
 
 	items := make([]list.Item, 0, len(keys))
 	for _, k := range keys {
@@ -177,7 +179,10 @@ func (m *AppListModel) updateListItems() {
 			description: entry.Description,
 			gitUrl:      entry.GitUrl,
 			installed:   true,
-			missing:     !exists[k],
+		}
+		// Apps not checked yet are shown as present
+		if exists, ok := m.exists[k]; ok {
+			item.missing = !exists
 		}
 		items = append(items, item)
 	}
@@ -191,6 +196,15 @@ func (m *AppListModel) updateListItems() {
 	}
 }
 
+// validateApps checks app directories in the background, since stat-ing
+// every app can be slow on network filesystems or with many apps
+func validateApps(idx *appindex.Index) tea.Cmd {
+	paths := idx.Paths()
+	return func() tea.Msg {
+		return tui.AppsValidatedMsg{Exists: appindex.ValidatePaths(paths)}
+	}
+}
+
 func splitAppKey(key string) (author, name string) {
 	parts := strings.SplitN(key, "/", 2)
 	author = parts[0]
@@ -203,6 +217,17 @@ func splitAppKey(key string) (author, name string) {
 	return author, name
 }
 
+// KeyHelp returns the keys shown in the help overlay
+func (m *AppListModel) KeyHelp() []key.Binding {
+	return []key.Binding{
+		m.keys.Up,
+		m.keys.Down,
+		m.keys.Filter,
+		tui.WithHelp(m.keys.Enter, "details"),
+		m.keys.Back,
+	}
+}
+
 // View renders the app list view
 func (m *AppListModel) View() string {
 	if m.loading {
@@ -251,7 +276,7 @@ func (m *AppListModel) emptyView() string {
 	b.WriteString("\n\n")
 
 	helpStyle := lipgloss.NewStyle().Foreground(styles.Muted).MaxWidth(contentWidth)
-	b.WriteString(helpStyle.Render("  Press esc to go back"))
+	b.WriteString(helpStyle.Render("  Press " + m.keys.Back.Help().Key + " to go back"))
 
 	return b.String()
 }
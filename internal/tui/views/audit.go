@@ -6,15 +6,16 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
-	"github.com/charmbracelet/lipgloss"
 	kioskexec "github.com/reflective-technologies/kiosk-cli/internal/exec"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/components"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
 )
 
@@ -39,13 +40,12 @@ type AuditModel struct {
 	result   string
 	error    error
 	ready    bool
+	started  time.Time
 }
 
 // NewAuditModel creates a new audit model
-func NewAuditModel() AuditModel {
-	s := spinner.New()
-	s.Spinner = spinner.Globe
-	s.Style = lipgloss.NewStyle().Foreground(styles.Primary)
+func NewAuditModel(spinnerStyle spinner.Spinner) AuditModel {
+	s := components.NewSpinnerModel(spinnerStyle)
 
 	return AuditModel{
 		keys:    tui.DefaultKeyMap(),
@@ -79,6 +79,7 @@ func (m *AuditModel) Init() tea.Cmd {
 	m.state = AuditStateInitial
 	m.result = ""
 	m.error = nil
+	m.started = time.Now()
 
 	return tea.Batch(
 		m.spinner.Tick,
@@ -92,7 +93,7 @@ func (m *AuditModel) runAudit() tea.Msg {
 		return tui.AuditCompleteMsg{Err: err}
 	}
 
-	cmd := kioskexec.ClaudeCmd("-p", kioskexec.AuditPrompt)
+	cmd := kioskexec.ClaudeCmd("-p", kioskexec.AuditPrompt(cwd))
 	cmd.Dir = cwd
 
 	var stdout, stderr bytes.Buffer
@@ -173,6 +174,24 @@ func (m AuditModel) renderMarkdown(content string) (string, error) {
 	return renderer.Render(content)
 }
 
+// Busy reports whether the audit is still running
+func (m *AuditModel) Busy() bool {
+	return m.state == AuditStateRunning
+}
+
+// KeyHelp returns the keys shown in the help overlay
+func (m *AuditModel) KeyHelp() []key.Binding {
+	if m.state == AuditStateComplete {
+		return []key.Binding{
+			tui.WithHelp(m.viewport.KeyMap.Up, "scroll up"),
+			tui.WithHelp(m.viewport.KeyMap.Down, "scroll down"),
+			m.viewport.KeyMap.PageDown,
+			m.keys.Back,
+		}
+	}
+	return []key.Binding{m.keys.Back}
+}
+
 // View renders the audit view
 func (m *AuditModel) View() string {
 	var b strings.Builder
@@ -187,6 +206,10 @@ func (m *AuditModel) View() string {
 		b.WriteString(m.spinner.View())
 		b.WriteString(" ")
 		b.WriteString(styles.MutedStyle.Render("Running security audit..."))
+		if note := components.ElapsedNote(time.Since(m.started)); note != "" {
+			b.WriteString(" ")
+			b.WriteString(styles.MutedStyle.Render("(" + note + ")"))
+		}
 		b.WriteString("\n\n")
 		b.WriteString(styles.MutedStyle.Render("This may take a minute. Scanning for secrets, credentials, and sensitive data."))
 
@@ -211,9 +234,12 @@ func (m *AuditModel) View() string {
 	helpStyle := styles.HelpStyle
 	if m.state == AuditStateComplete {
 		scrollPercent := int(m.viewport.ScrollPercent() * 100)
-		b.WriteString(helpStyle.Render("↑/↓ scroll • esc back • " + strconv.Itoa(scrollPercent) + "%"))
+		b.WriteString(helpStyle.Render(tui.HelpLine(
+			tui.Combined("scroll", m.viewport.KeyMap.Up, m.viewport.KeyMap.Down),
+			m.keys.Back,
+		) + " • " + strconv.Itoa(scrollPercent) + "%"))
 	} else {
-		b.WriteString(helpStyle.Render("Press esc to cancel"))
+		b.WriteString(helpStyle.Render("Press " + m.keys.Back.Help().Key + " to cancel"))
 	}
 
 	return b.String()
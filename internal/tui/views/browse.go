@@ -2,7 +2,9 @@ package views
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -10,33 +12,113 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/reflective-technologies/kiosk-cli/internal/api"
+	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
 	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	kioskexec "github.com/reflective-technologies/kiosk-cli/internal/exec"
+	"github.com/reflective-technologies/kiosk-cli/internal/giturl"
 	"github.com/reflective-technologies/kiosk-cli/internal/prefetch"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/components"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
 )
 
 // browseItem represents an app in the browse list
 type browseItem struct {
 	app api.App
+	key string // index key (org/repo) if the app is installed, else empty
 }
 
 func (i browseItem) Title() string {
-	// Format: {APP_NAME} by {CREATOR}  | # of Installs
-	title := i.app.Name
-	if i.app.Creator != nil && i.app.Creator.Username != "" {
-		title = fmt.Sprintf("%s by %s", title, i.app.Creator.Username)
+	// Format: {APP_NAME} ✓ by {CREATOR}  | # of Installs  | updated {AGO}
+	title := strings.TrimSpace(i.app.Name)
+	if title == "" {
+		title = i.app.ID
+	}
+	if i.key != "" {
+		title += " ✓"
+	}
+	if creator := creatorLabel(i.app); creator != "" {
+		title = fmt.Sprintf("%s by %s", title, creator)
 	}
 	if i.app.InstallCount > 0 {
 		installText := "install"
 		if i.app.InstallCount != 1 {
 			installText = "installs"
 		}
-		title = fmt.Sprintf("%s  | %d %s", title, i.app.InstallCount, installText)
+		title = fmt.Sprintf("%s  | %s %s", title, formatCount(i.app.InstallCount), installText)
+	}
+	if updated, ok := i.app.ParsedUpdatedAt(); ok {
+		title = fmt.Sprintf("%s  | updated %s", title, relativeTime(updated))
 	}
 	return title
 }
 
+// creatorName returns the app creator's username, or "" if the API sent no
+// creator or one without a username
+func creatorName(app api.App) string {
+	if app.Creator == nil {
+		return ""
+	}
+	return strings.TrimSpace(app.Creator.Username)
+}
+
+// creatorLabel shows who published app: an initials badge and their name,
+// or their username if the API sent no name
+func creatorLabel(app api.App) string {
+	username := creatorName(app)
+	if username == "" {
+		return ""
+	}
+	display := username
+	if name := strings.TrimSpace(app.Creator.Name); name != "" {
+		display = name
+	}
+	return components.InitialsBadge(username, app.Creator.Name) + " " + display
+}
+
+// relativeTime describes t relative to now: "just now", "3 days ago".
+// Anything older than a year is shown as a date.
+func relativeTime(t time.Time) string {
+	d := time.Since(t)
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return "1 " + unit + " ago"
+		}
+		return fmt.Sprintf("%d %ss ago", n, unit)
+	}
+	switch {
+	case d < time.Minute:
+		// Also covers timestamps slightly in the future from clock skew
+		return "just now"
+	case d < time.Hour:
+		return plural(int(d/time.Minute), "minute")
+	case d < 24*time.Hour:
+		return plural(int(d/time.Hour), "hour")
+	case d < 30*24*time.Hour:
+		return plural(int(d/(24*time.Hour)), "day")
+	case d < 365*24*time.Hour:
+		return plural(int(d/(30*24*time.Hour)), "month")
+	}
+	return t.Local().Format("Jan 2, 2006")
+}
+
+// formatCount abbreviates large counts for display: 950, 12.3k, 1.2M
+func formatCount(n int) string {
+	switch {
+	case n < 1000:
+		return strconv.Itoa(n)
+	case n < 999_950:
+		return trimZeroDecimal(float64(n)/1000) + "k"
+	default:
+		return trimZeroDecimal(float64(n)/1_000_000) + "M"
+	}
+}
+
+// trimZeroDecimal formats f with one decimal place, dropping a trailing ".0"
+func trimZeroDecimal(f float64) string {
+	return strings.TrimSuffix(strconv.FormatFloat(f, 'f', 1, 64), ".0")
+}
+
 func (i browseItem) Description() string {
 	return i.app.Description
 }
@@ -44,7 +126,7 @@ func (i browseItem) Description() string {
 func (i browseItem) FilterValue() string {
 	filterStr := i.app.Name + " " + i.app.Description
 	if i.app.Creator != nil {
-		filterStr += " " + i.app.Creator.Username
+		filterStr += " " + i.app.Creator.Username + " " + i.app.Creator.Name
 	}
 	return filterStr
 }
@@ -58,20 +140,62 @@ type BrowseModel struct {
 	keys    tui.KeyMap
 	loading bool
 	err     error
+	errAt   time.Time // when a remembered prefetch error happened; zero for a fresh one
 	apps    []api.App
 
 	// Pagination state
 	nextCursor      *string // cursor for next page, nil if no more pages
 	loadingMore     bool    // true when loading additional pages
 	fetchGeneration uint64  // incremented on Init() to invalidate in-flight fetches
+	pendingGoToEnd  bool    // G/end pressed while a page was loading; follow to the new bottom
+	loadMoreQueued  bool    // a debounced page fetch is scheduled but not yet sent
+	loadMoreSeq     uint64  // identifies the latest scheduled fetch; older ticks are ignored
+
+	// since hides apps not created or updated after this time; zero shows all
+	since time.Time
+
+	// creator hides apps published by anyone else; empty shows all
+	creator string
 }
 
+// browseLoadMoreDebounce is how long the cursor must rest near the bottom of
+// the list before the next page is fetched, so scrolling back and forth
+// across the threshold doesn't issue a fetch for every key press
+const browseLoadMoreDebounce = 150 * time.Millisecond
+
+// browseLoadMoreMsg fires when a debounced page fetch is due
+type browseLoadMoreMsg struct {
+	seq        uint64
+	generation uint64
+}
+
+// browseNewWindow is how far back the "new" filter looks
+const browseNewWindow = 7 * 24 * time.Hour
+
+var browseNewKey = tui.ViewKey("browse", key.NewBinding(
+	key.WithKeys("n"),
+	key.WithHelp("n", "new this week"),
+))
+
+var browseCreatorKey = tui.ViewKey("browse", key.NewBinding(
+	key.WithKeys("a"),
+	key.WithHelp("a", "more by this author"),
+))
+
+var browseRetryKey = tui.ViewKey("browse", key.NewBinding(
+	key.WithKeys("r"),
+	key.WithHelp("r", "retry"),
+))
+
+var browseCopyKey = tui.ViewKey("browse", key.NewBinding(
+	key.WithKeys("c"),
+	key.WithHelp("c", "copy install command"),
+))
+
 // NewBrowseModel creates a new browse model
-func NewBrowseModel() BrowseModel {
+func NewBrowseModel(spinnerStyle spinner.Spinner) BrowseModel {
 	// Create spinner
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(styles.Primary)
+	s := components.NewSpinnerModel(spinnerStyle)
 
 	// Create a custom delegate with multi-line description support
 	delegate := NewAppItemDelegate()
@@ -85,6 +209,9 @@ func NewBrowseModel() BrowseModel {
 		Foreground(styles.Primary)
 	l.Styles.FilterPrompt = lipgloss.NewStyle().Foreground(styles.Primary)
 	l.Styles.FilterCursor = lipgloss.NewStyle().Foreground(styles.Secondary)
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{browseNewKey, browseCreatorKey, browseCopyKey}
+	}
 
 	return BrowseModel{
 		list:    l,
@@ -101,6 +228,25 @@ func (m *BrowseModel) SetSize(width, height int) {
 	m.list.SetSize(width, height-2)
 }
 
+// Resume returns to the list as it was left, keeping the apps loaded so far
+// and the selection, and refreshing which are installed. Until apps have
+// loaded it starts over like Init.
+func (m *BrowseModel) Resume() tea.Cmd {
+	if m.loading || m.err != nil || m.apps == nil {
+		return m.Init()
+	}
+
+	// Pages requested before leaving were delivered to another view, so
+	// forget them; the next move near the bottom asks again
+	m.fetchGeneration++
+	m.loadingMore = false
+	m.pendingGoToEnd = false
+	m.loadMoreQueued = false
+
+	m.updateListItems()
+	return nil
+}
+
 // Init initializes the browse model
 func (m *BrowseModel) Init() tea.Cmd {
 	// Increment generation to invalidate any in-flight pagination fetches
@@ -109,6 +255,8 @@ func (m *BrowseModel) Init() tea.Cmd {
 	// Reset pagination state
 	m.loadingMore = false
 	m.nextCursor = nil
+	m.pendingGoToEnd = false
+	m.loadMoreQueued = false
 
 	// Check if we have prefetched data available
 	cache := prefetch.GetCache()
@@ -124,16 +272,41 @@ func (m *BrowseModel) Init() tea.Cmd {
 		return nil
 	}
 
-	// If there was a cached error, reset and start a fresh prefetch
-	if result.Loaded && result.Err != nil {
-		cache.ResetBrowseApps()
-		cache.StartBrowseAppsPrefetch()
+	// A recent failure is shown rather than retried, so visiting Browse
+	// while the API is down doesn't ask it again every time; r retries
+	if result.Loaded && !result.RetryDue() {
+		m.loading = false
+		m.apps = nil
+		m.err = result.Err
+		m.errAt = result.FailedAt
+		return nil
 	}
+	if result.Loaded {
+		return m.retry()
+	}
+
+	// Data not ready yet - show spinner and wait for prefetch to complete
+	m.loading = true
+	m.apps = nil
+	m.err = nil
+	m.errAt = time.Time{}
+	return tea.Batch(
+		m.spinner.Tick,
+		m.waitForPrefetch,
+	)
+}
+
+// retry discards the cached first page, or its error, and fetches it again
+func (m *BrowseModel) retry() tea.Cmd {
+	cfg, _ := config.Load()
+	cache := prefetch.GetCache()
+	cache.ResetBrowseApps()
+	cache.StartBrowseAppsPrefetch(prefetch.PageSize(cfg, m.list.Paginator.PerPage))
 
-	// Data not ready yet (or retrying after error) - show spinner and wait for prefetch to complete
 	m.loading = true
 	m.apps = nil
 	m.err = nil
+	m.errAt = time.Time{}
 	return tea.Batch(
 		m.spinner.Tick,
 		m.waitForPrefetch,
@@ -159,30 +332,57 @@ func (m *BrowseModel) fetchMoreApps() tea.Cmd {
 
 	cursor := *m.nextCursor
 	generation := m.fetchGeneration // capture current generation
+	visibleRows := m.list.Paginator.PerPage
 	return func() tea.Msg {
 		cfg, err := config.Load()
 		if err != nil {
-			return tui.BrowseAppsPageLoadedMsg{Err: err, Generation: generation}
+			return tui.BrowseAppsPageLoadedMsg{Err: err, Cursor: cursor, Generation: generation}
 		}
 
-		client := api.NewClient(cfg.APIUrl)
-		result, err := client.ListAppsPaginated(prefetch.DefaultPageSize, cursor)
+		client := api.NewClient(cfg.APIUrl).WithRegistry(cfg.Registry)
+		result, err := client.ListAppsPaginated(prefetch.PageSize(cfg, visibleRows), cursor)
 		if err != nil {
-			return tui.BrowseAppsPageLoadedMsg{Err: err, Generation: generation}
+			return tui.BrowseAppsPageLoadedMsg{Err: err, Cursor: cursor, Generation: generation}
 		}
 
 		return tui.BrowseAppsPageLoadedMsg{
 			Apps:       result.Apps,
 			NextCursor: result.NextCursor,
+			Cursor:     cursor,
 			Generation: generation,
 		}
 	}
 }
 
+// scheduleLoadMore queues a page fetch after browseLoadMoreDebounce. The
+// queued flag is set before the command is returned so further key presses
+// near the bottom don't schedule another.
+func (m *BrowseModel) scheduleLoadMore() tea.Cmd {
+	m.loadMoreQueued = true
+	m.loadMoreSeq++
+	msg := browseLoadMoreMsg{seq: m.loadMoreSeq, generation: m.fetchGeneration}
+	return tea.Tick(browseLoadMoreDebounce, func(time.Time) tea.Msg {
+		return msg
+	})
+}
+
+// startLoadMore marks a page fetch as in flight and returns the command that
+// performs it. loadingMore is set here, before the fetch runs, so nothing
+// else can start a second fetch for the same cursor.
+func (m *BrowseModel) startLoadMore() tea.Cmd {
+	m.loadMoreQueued = false
+	m.loadingMore = true
+	return tea.Batch(m.spinner.Tick, m.fetchMoreApps())
+}
+
 // Update handles messages for the browse view
 func (m *BrowseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	// Set when the user jumps rather than steps through the list, so the
+	// proximity check below doesn't miss the bottom of the loaded pages
+	jumped := false
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		// Don't process key events when filtering
@@ -191,20 +391,73 @@ func (m *BrowseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		switch {
+		case m.err != nil && key.Matches(msg, browseRetryKey):
+			return m, m.retry()
+
+		case key.Matches(msg, m.list.KeyMap.GoToEnd):
+			jumped = true
+			if m.loadingMore {
+				m.pendingGoToEnd = true
+			}
+
+		case key.Matches(msg, m.list.KeyMap.NextPage):
+			jumped = true
+
+		case key.Matches(msg, m.list.KeyMap.GoToStart, m.list.KeyMap.PrevPage, m.list.KeyMap.CursorUp):
+			m.pendingGoToEnd = false
+
+		case key.Matches(msg, browseNewKey):
+			if !m.loading && m.err == nil {
+				if m.since.IsZero() {
+					m.SetSince(time.Now().Add(-browseNewWindow))
+				} else {
+					m.SetSince(time.Time{})
+				}
+				return m, nil
+			}
+
+		case key.Matches(msg, browseCreatorKey):
+			if !m.loading && m.err == nil {
+				if m.creator != "" {
+					m.SetCreator("")
+				} else if item, ok := m.list.SelectedItem().(browseItem); ok && item.app.Creator != nil {
+					m.SetCreator(item.app.Creator.Username)
+				}
+				// The author may have more apps in pages not loaded yet
+				if m.shouldLoadMore(true) {
+					return m, m.startLoadMore()
+				}
+				return m, nil
+			}
+
+		case key.Matches(msg, browseCopyKey):
+			if !m.loading && m.err == nil {
+				if item, ok := m.list.SelectedItem().(browseItem); ok {
+					command := installCommand(item.app)
+					if err := kioskexec.CopyToClipboard(command); err != nil {
+						return m, m.list.NewStatusMessage(styles.ErrorStyle.Render("Couldn't copy: " + err.Error()))
+					}
+					return m, m.list.NewStatusMessage(styles.SuccessStyle.Render("Copied: " + command))
+				}
+			}
+
 		case key.Matches(msg, m.keys.Back):
+			if m.creator != "" && m.list.FilterState() == list.Unfiltered {
+				m.SetCreator("")
+				return m, nil
+			}
 			return m, func() tea.Msg { return tui.GoBackMsg{} }
 
 		case key.Matches(msg, m.keys.Enter):
 			if !m.loading && m.err == nil {
 				if item, ok := m.list.SelectedItem().(browseItem); ok {
 					app := item.app // capture for closure
-					return m, func() tea.Msg {
-						return tui.ShowAppDetailMsg{
-							App:         &app,
-							IsInstalled: false,
-							AppKey:      app.ID,
-						}
+					msg := tui.ShowAppDetailMsg{App: &app, AppKey: app.ID}
+					if item.key != "" {
+						msg.IsInstalled = true
+						msg.AppKey = item.key
 					}
+					return m, func() tea.Msg { return msg }
 				}
 			}
 		}
@@ -227,20 +480,39 @@ func (m *BrowseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.nextCursor = msg.NextCursor
 		m.updateListItems()
 
+	case browseLoadMoreMsg:
+		if msg.generation != m.fetchGeneration || msg.seq != m.loadMoreSeq {
+			return m, nil
+		}
+		m.loadMoreQueued = false
+		// The cursor may have moved away from the bottom while we waited
+		if !m.loading && m.err == nil && m.shouldLoadMore(false) {
+			return m, m.startLoadMore()
+		}
+		return m, nil
+
 	case tui.BrowseAppsPageLoadedMsg:
-		// Ignore stale messages from previous sessions (e.g., user navigated away and back)
-		if msg.Generation != m.fetchGeneration {
+		// Ignore stale messages from previous sessions (e.g., user navigated
+		// away and back) and pages other than the one we asked for
+		if msg.Generation != m.fetchGeneration || m.nextCursor == nil || msg.Cursor != *m.nextCursor {
 			return m, nil
 		}
 		m.loadingMore = false
 		if msg.Err != nil {
 			// Don't show error for pagination failures, just stop loading
+			m.pendingGoToEnd = false
 			return m, nil
 		}
 		// Append new apps to existing list
 		m.apps = append(m.apps, msg.Apps...)
 		m.nextCursor = msg.NextCursor
 		m.updateListItems()
+		if m.pendingGoToEnd {
+			m.pendingGoToEnd = false
+			m.list.Select(len(m.list.VisibleItems()) - 1)
+			// Landing on the last row fetches the next page right away
+			jumped = true
+		}
 	}
 
 	// Update the list
@@ -249,48 +521,141 @@ func (m *BrowseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.list, cmd = m.list.Update(msg)
 		cmds = append(cmds, cmd)
 
-		// Check if we should load more apps (when near the bottom of the list)
-		if m.shouldLoadMore() {
-			m.loadingMore = true
-			cmds = append(cmds, m.spinner.Tick, m.fetchMoreApps())
+		// Check if we should load more apps (when near the bottom of the
+		// list). Jumps fetch right away; stepping is debounced.
+		if m.shouldLoadMore(jumped) {
+			if jumped {
+				cmds = append(cmds, m.startLoadMore())
+			} else {
+				cmds = append(cmds, m.scheduleLoadMore())
+			}
 		}
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
-// shouldLoadMore returns true if we should fetch the next page of apps
-func (m *BrowseModel) shouldLoadMore() bool {
+// shouldLoadMore returns true if we should fetch the next page of apps.
+// jumped is true when the cursor moved by a page or to the end, in which
+// case landing on the last page is enough to fetch more.
+func (m *BrowseModel) shouldLoadMore(jumped bool) bool {
 	// Don't load more if already loading or no more pages
 	if m.loadingMore || m.nextCursor == nil {
 		return false
 	}
 
+	// A debounced fetch is already queued; a jump skips the wait
+	if m.loadMoreQueued && !jumped {
+		return false
+	}
+
 	// Don't load more when filtering
 	if m.list.FilterState() == list.Filtering {
 		return false
 	}
 
-	// Load more when user is within 3 items of the bottom
-	totalItems := len(m.list.Items())
+	// Index() is relative to the visible items, which differ from Items()
+	// when a filter is applied
+	totalItems := len(m.list.VisibleItems())
 	if totalItems == 0 {
 		return false
 	}
 
+	if jumped && m.list.Paginator.OnLastPage() {
+		return true
+	}
+
+	// Load more when user is within 3 items of the bottom
 	currentIndex := m.list.Index()
 	threshold := 3
 
 	return currentIndex >= totalItems-threshold
 }
 
+// SetSince limits the list to apps created or updated after t.
+// Apps without timestamps are hidden while a filter is set.
+// A zero t shows all apps.
+func (m *BrowseModel) SetSince(t time.Time) {
+	m.since = t
+	m.updateTitle()
+	m.updateListItems()
+}
+
+// SetCreator limits the list to apps published by username.
+// An empty username shows all apps.
+func (m *BrowseModel) SetCreator(username string) {
+	m.creator = username
+	m.updateTitle()
+	m.updateListItems()
+	m.list.Select(0)
+}
+
+// updateTitle describes the active filters in the list title
+func (m *BrowseModel) updateTitle() {
+	m.list.Title = "Browse Apps"
+	if m.creator != "" {
+		m.list.Title += " by " + m.creator
+	}
+	if !m.since.IsZero() {
+		m.list.Title += " (since " + m.since.Format("Jan 2") + ")"
+	}
+}
+
 func (m *BrowseModel) updateListItems() {
-	items := make([]list.Item, 0, len(m.apps))
-	for _, app := range m.apps {
-		items = append(items, browseItem{app: app})
+	apps := api.FilterCreator(api.FilterSince(m.apps, m.since), m.creator)
+	idx, _ := appindex.Load()
+	items := make([]list.Item, 0, len(apps))
+	for _, app := range apps {
+		items = append(items, browseItem{app: app, key: installedKey(idx, app)})
 	}
 	m.list.SetItems(items)
 }
 
+// installedKey returns the index key of app if it is installed, or "". An
+// entry under the app's org/repo or ID only counts if it was cloned from the
+// same repository; apps on hosts giturl doesn't know, e.g. self-hosted
+// GitLab, are matched by their git URL.
+func installedKey(idx *appindex.Index, app api.App) string {
+	if idx == nil {
+		return ""
+	}
+	for _, key := range []string{giturl.ExtractOrgRepo(app.GitUrl), app.ID} {
+		if key != "" && idx.Has(key) && idx.Conflict(key, app.GitUrl) == nil {
+			return key
+		}
+	}
+	return idx.FindByGitURL(app.GitUrl)
+}
+
+// installCommand returns the command that installs app, naming it by
+// org/repo when its git URL has one and by its ID otherwise
+func installCommand(app api.App) string {
+	name := giturl.ExtractOrgRepo(app.GitUrl)
+	if name == "" {
+		name = app.ID
+	}
+	return "kiosk run " + name
+}
+
+// KeyHelp returns the keys shown in the help overlay
+func (m *BrowseModel) KeyHelp() []key.Binding {
+	if m.err != nil {
+		return []key.Binding{browseRetryKey, m.keys.Back}
+	}
+	return []key.Binding{
+		m.keys.Up,
+		m.keys.Down,
+		m.list.KeyMap.NextPage,
+		m.list.KeyMap.GoToEnd,
+		m.keys.Filter,
+		browseNewKey,
+		browseCreatorKey,
+		browseCopyKey,
+		tui.WithHelp(m.keys.Enter, "details"),
+		m.keys.Back,
+	}
+}
+
 // View renders the browse view
 func (m *BrowseModel) View() string {
 	if m.loading {
@@ -344,10 +709,14 @@ func (m *BrowseModel) errorView() string {
 	b.WriteString(titleStyle.Render("Browse Apps"))
 	b.WriteString("\n\n")
 
+	if !m.errAt.IsZero() {
+		b.WriteString(styles.MutedStyle.Render("Last attempt failed " + relativeTime(m.errAt)))
+		b.WriteString("\n")
+	}
 	b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
 	b.WriteString("\n\n")
 
-	b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render("esc go back"))
+	b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render(tui.HelpLine(browseRetryKey, tui.WithHelp(m.keys.Back, "go back"))))
 
 	return b.String()
 }
@@ -370,7 +739,7 @@ func (m *BrowseModel) emptyView() string {
 	b.WriteString(contentStyle.Render("No apps available yet."))
 	b.WriteString("\n\n")
 
-	b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render("esc go back"))
+	b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render(tui.HelpLine(tui.WithHelp(m.keys.Back, "go back"))))
 
 	return b.String()
 }
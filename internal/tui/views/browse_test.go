@@ -0,0 +1,110 @@
+package views
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/reflective-technologies/kiosk-cli/internal/api"
+	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/components"
+)
+
+func TestInstalledKey(t *testing.T) {
+	idx := &appindex.Index{Apps: map[string]*appindex.AppEntry{
+		"acme/tool":      {GitUrl: "git@gitlab.com:acme/tool.git"},
+		"acme/notes":     {GitUrl: "https://bitbucket.org/acme/notes"},
+		"internal-app":   {GitUrl: "https://git.example.com/team/internal-app.git"},
+		"acme/unrelated": {GitUrl: "https://github.com/acme/unrelated"},
+	}}
+
+	tests := []struct {
+		name string
+		app  api.App
+		want string
+	}{
+		{"gitlab", api.App{ID: "a1", GitUrl: "https://gitlab.com/acme/tool"}, "acme/tool"},
+		{"bitbucket", api.App{ID: "a2", GitUrl: "https://bitbucket.org/acme/notes.git"}, "acme/notes"},
+		{"self-hosted", api.App{ID: "a3", GitUrl: "https://git.example.com/team/internal-app"}, "internal-app"},
+		{"not installed", api.App{ID: "a4", GitUrl: "https://gitlab.com/acme/other"}, ""},
+		{"same org/repo on another host", api.App{ID: "a5", GitUrl: "https://gitlab.com/acme/unrelated"}, ""},
+		{"self-hosted on another host", api.App{ID: "a6", GitUrl: "https://git.other.com/team/internal-app"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := installedKey(idx, tt.app); got != tt.want {
+				t.Errorf("installedKey() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBrowseResumeKeepsSelection(t *testing.T) {
+	m := NewBrowseModel(components.SpinnerDot)
+	m.SetSize(80, 40)
+
+	apps := make([]api.App, 30)
+	for i := range apps {
+		apps[i] = api.App{ID: fmt.Sprintf("app-%d", i), Name: fmt.Sprintf("App %d", i)}
+	}
+	m.Update(tui.BrowseAppsLoadedMsg{Apps: apps})
+	m.list.Select(20)
+
+	m.Resume()
+	if got := m.list.Index(); got != 20 {
+		t.Errorf("after Resume, selected index = %d; want 20", got)
+	}
+	if got := len(m.list.Items()); got != len(apps) {
+		t.Errorf("after Resume, %d items; want %d", got, len(apps))
+	}
+}
+
+func TestInstallCommand(t *testing.T) {
+	tests := []struct {
+		app  api.App
+		want string
+	}{
+		{api.App{ID: "a1", GitUrl: "https://github.com/acme/tool.git"}, "kiosk run acme/tool"},
+		{api.App{ID: "a2", GitUrl: "https://git.example.com/team/tool.git"}, "kiosk run a2"},
+	}
+	for _, tt := range tests {
+		if got := installCommand(tt.app); got != tt.want {
+			t.Errorf("installCommand(%q) = %q; want %q", tt.app.GitUrl, got, tt.want)
+		}
+	}
+}
+
+func TestBrowsePendingGoToEndFetchesNextPage(t *testing.T) {
+	m := NewBrowseModel(components.SpinnerDot)
+	m.SetSize(80, 40)
+
+	page := func(from int) []api.App {
+		apps := make([]api.App, 30)
+		for i := range apps {
+			apps[i] = api.App{ID: fmt.Sprintf("app-%d", from+i), Name: fmt.Sprintf("App %d", from+i)}
+		}
+		return apps
+	}
+	first, second := "c1", "c2"
+	m.Update(tui.BrowseAppsLoadedMsg{Apps: page(0), NextCursor: &first})
+	m.list.Select(29)
+	m.startLoadMore()
+
+	// G while the second page loads follows to its last row
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	m.Update(tui.BrowseAppsPageLoadedMsg{Apps: page(30), NextCursor: &second, Cursor: first, Generation: m.fetchGeneration})
+	if got := m.list.Index(); got != 59 {
+		t.Errorf("selected index = %d; want 59", got)
+	}
+	if !m.loadingMore || m.loadMoreQueued {
+		t.Errorf("loadingMore = %v, loadMoreQueued = %v; want the next page fetched right away", m.loadingMore, m.loadMoreQueued)
+	}
+
+	// Nothing more is fetched once the last page is in
+	m.Update(tui.BrowseAppsPageLoadedMsg{Apps: page(60), Cursor: second, Generation: m.fetchGeneration})
+	if m.loadingMore || m.loadMoreQueued {
+		t.Errorf("after the last page, loadingMore = %v, loadMoreQueued = %v; want neither", m.loadingMore, m.loadMoreQueued)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	reflowtruncate "github.com/muesli/reflow/truncate"
 	"github.com/muesli/reflow/wordwrap"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
 )
@@ -143,13 +144,14 @@ func (d AppItemDelegate) Render(w io.Writer, m list.Model, index int, item list.
 	}
 }
 
-// truncate truncates a string to the given width
+// truncate truncates a string to the given display width, leaving styling
+// such as a creator's badge intact
 func truncate(s string, width int) string {
-	if len(s) <= width {
+	if lipgloss.Width(s) <= width {
 		return s
 	}
 	if width <= 3 {
-		return s[:width]
+		return reflowtruncate.String(s, uint(max(width, 0)))
 	}
-	return s[:width-3] + "..."
+	return reflowtruncate.StringWithTail(s, uint(width), "...")
 }
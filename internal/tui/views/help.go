@@ -48,6 +48,11 @@ func (m *HelpModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// KeyHelp returns the keys shown in the help overlay
+func (m *HelpModel) KeyHelp() []key.Binding {
+	return []key.Binding{m.keys.Back}
+}
+
 // View renders the help view
 func (m *HelpModel) View() string {
 	var b strings.Builder
@@ -64,24 +69,22 @@ func (m *HelpModel) View() string {
 	keyStyle := lipgloss.NewStyle().Foreground(styles.Secondary).Width(15)
 	descStyle := lipgloss.NewStyle().Foreground(styles.Muted)
 
-	shortcuts := []struct {
-		key  string
-		desc string
-	}{
-		{"↑/k", "Move up"},
-		{"↓/j", "Move down"},
-		{"enter", "Select / Confirm"},
-		{"ctrl+k", "Suspend Claude session"},
-		{"esc", "Go back"},
-		{"q", "Quit (from home)"},
-		{"/", "Filter list"},
-		{"?", "Toggle help"},
+	// Built from the key map, so remapped keys show as they are
+	shortcuts := []key.Binding{
+		tui.WithHelp(m.keys.Up, "Move up"),
+		tui.WithHelp(m.keys.Down, "Move down"),
+		tui.WithHelp(m.keys.Enter, "Select / Confirm"),
+		key.NewBinding(key.WithHelp("ctrl+k", "End Claude session (resume later)")),
+		tui.WithHelp(m.keys.Back, "Go back"),
+		tui.WithHelp(m.keys.Quit, "Quit (from home)"),
+		tui.WithHelp(m.keys.Filter, "Filter list"),
+		tui.WithHelp(m.keys.Help, "Toggle help"),
 	}
 
 	for _, s := range shortcuts {
 		b.WriteString("  ")
-		b.WriteString(keyStyle.Render(s.key))
-		b.WriteString(descStyle.Render(s.desc))
+		b.WriteString(keyStyle.Render(s.Help().Key))
+		b.WriteString(descStyle.Render(s.Help().Desc))
 		b.WriteString("\n")
 	}
 
@@ -126,7 +129,7 @@ func (m *HelpModel) View() string {
 	b.WriteString("\n\n")
 
 	// Help footer
-	b.WriteString(styles.HelpStyle.Render("esc go back"))
+	b.WriteString(styles.HelpStyle.Render(tui.HelpLine(tui.WithHelp(m.keys.Back, "go back"))))
 
 	return b.String()
 }
@@ -1,22 +1,31 @@
 package views
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/reflective-technologies/kiosk-cli/internal/appindex"
+	"github.com/reflective-technologies/kiosk-cli/internal/events"
+	"github.com/reflective-technologies/kiosk-cli/internal/prefetch"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
 )
 
+// maxRecentApps is the number of recently used apps shown on the home view
+const maxRecentApps = 3
+
 // HomeModel is the model for the home/main menu view
 type HomeModel struct {
-	width  int
-	height int
-	cursor int
-	items  []menuItem
-	keys   tui.KeyMap
+	width     int
+	height    int
+	cursor    int
+	items     []menuItem
+	recent    []menuItem // recently used installed apps
+	suggested []menuItem // apps from the browse list that aren't installed
+	keys      tui.KeyMap
 }
 
 type menuItem struct {
@@ -68,32 +77,160 @@ func (m *HomeModel) SetSize(width, height int) {
 
 // Init initializes the home model
 func (m *HomeModel) Init() tea.Cmd {
-	return nil
+	return tea.Batch(loadRecentApps, loadSuggestedApps)
+}
+
+// Messages
+type homeRecentLoadedMsg struct {
+	items []menuItem
+}
+
+type homeSuggestedLoadedMsg struct {
+	items []menuItem
+}
+
+// loadRecentApps builds the recently used section from the local event log,
+// falling back to the index UpdatedAt timestamps when the log is empty or disabled.
+func loadRecentApps() tea.Msg {
+	idx, err := appindex.Load()
+	if err != nil || idx.Count() == 0 {
+		return homeRecentLoadedMsg{}
+	}
+
+	var keys []string
+	seen := make(map[string]bool)
+
+	// Newest events first; only installed apps are launchable
+	log, _ := events.Load()
+	for i := len(log) - 1; i >= 0 && len(keys) < maxRecentApps; i-- {
+		e := log[i]
+		if e.Type == events.Remove || seen[e.AppKey] || !idx.Has(e.AppKey) {
+			continue
+		}
+		seen[e.AppKey] = true
+		keys = append(keys, e.AppKey)
+	}
+
+	if len(keys) == 0 {
+		keys = idx.List()
+		sort.Slice(keys, func(i, j int) bool {
+			ti, tj := idx.Get(keys[i]).UpdatedAt, idx.Get(keys[j]).UpdatedAt
+			if ti.Equal(tj) {
+				return keys[i] < keys[j]
+			}
+			return ti.After(tj)
+		})
+		if len(keys) > maxRecentApps {
+			keys = keys[:maxRecentApps]
+		}
+	}
+
+	items := make([]menuItem, 0, len(keys))
+	for _, k := range keys {
+		entry := idx.Get(k)
+		_, name := splitAppKey(k)
+		appKey, gitURL := k, entry.GitUrl // capture for closure
+		items = append(items, menuItem{
+			title:       name,
+			description: entry.Description,
+			action: func() tea.Msg {
+				return tui.RunAppMsg{AppKey: appKey, GitURL: gitURL}
+			},
+		})
+	}
+
+	return homeRecentLoadedMsg{items: items}
+}
+
+// loadSuggestedApps picks an app from the prefetched browse list that isn't installed yet.
+func loadSuggestedApps() tea.Msg {
+	result := prefetch.GetCache().WaitForBrowseApps()
+	if result.Err != nil || len(result.Apps) == 0 {
+		return homeSuggestedLoadedMsg{}
+	}
+
+	idx, err := appindex.Load()
+	if err != nil {
+		return homeSuggestedLoadedMsg{}
+	}
+
+	for _, app := range result.Apps {
+		if installedKey(idx, app) != "" {
+			continue
+		}
+		app := app // capture for closure
+		return homeSuggestedLoadedMsg{items: []menuItem{{
+			title:       app.Name,
+			description: app.Description,
+			action: func() tea.Msg {
+				return tui.ShowAppDetailMsg{
+					App:         &app,
+					IsInstalled: false,
+					AppKey:      app.ID,
+				}
+			},
+		}}}
+	}
+
+	return homeSuggestedLoadedMsg{}
+}
+
+// allItems returns the selectable rows in display order
+func (m *HomeModel) allItems() []menuItem {
+	all := make([]menuItem, 0, len(m.items)+len(m.recent)+len(m.suggested))
+	all = append(all, m.items...)
+	all = append(all, m.recent...)
+	all = append(all, m.suggested...)
+	return all
+}
+
+// clampCursor keeps the cursor on a valid row after sections change
+func (m *HomeModel) clampCursor() {
+	if total := len(m.allItems()); m.cursor >= total {
+		m.cursor = total - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
 }
 
 // Update handles messages for the home view
 func (m *HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		items := m.allItems()
 		switch {
 		case key.Matches(msg, m.keys.Up):
 			if m.cursor > 0 {
 				m.cursor--
 			}
 		case key.Matches(msg, m.keys.Down):
-			if m.cursor < len(m.items)-1 {
+			if m.cursor < len(items)-1 {
 				m.cursor++
 			}
 		case key.Matches(msg, m.keys.Enter):
-			if m.cursor < len(m.items) {
-				return m, func() tea.Msg { return m.items[m.cursor].action() }
+			if m.cursor < len(items) {
+				return m, items[m.cursor].action
 			}
 		}
+
+	case homeRecentLoadedMsg:
+		m.recent = msg.items
+		m.clampCursor()
+
+	case homeSuggestedLoadedMsg:
+		m.suggested = msg.items
+		m.clampCursor()
 	}
 
 	return m, nil
 }
 
+// KeyHelp returns the keys shown in the help overlay
+func (m *HomeModel) KeyHelp() []key.Binding {
+	return []key.Binding{m.keys.Up, m.keys.Down, tui.WithHelp(m.keys.Enter, "open")}
+}
+
 // View renders the home view
 func (m *HomeModel) View() string {
 	var b strings.Builder
@@ -111,35 +248,53 @@ func (m *HomeModel) View() string {
 	}
 
 	// Menu items - use MaxWidth to truncate if needed
-	for i, item := range m.items {
+	m.renderItems(&b, m.items, 0, contentWidth)
+
+	// Launcher sections are only shown when there is something to show
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Muted).MaxWidth(contentWidth)
+	offset := len(m.items)
+	if len(m.recent) > 0 {
+		b.WriteString("\n")
+		b.WriteString(sectionStyle.Render("Recently used"))
+		b.WriteString("\n")
+		m.renderItems(&b, m.recent, offset, contentWidth)
+	}
+	offset += len(m.recent)
+	if len(m.suggested) > 0 {
+		b.WriteString("\n")
+		b.WriteString(sectionStyle.Render("Suggested"))
+		b.WriteString("\n")
+		m.renderItems(&b, m.suggested, offset, contentWidth)
+	}
+
+	// Help
+	b.WriteString("\n")
+	helpStyle := lipgloss.NewStyle().Foreground(styles.Muted).MaxWidth(contentWidth)
+	b.WriteString(helpStyle.Render(tui.HelpLine(tui.Combined("navigate", m.keys.Up, m.keys.Down), m.keys.Enter, m.keys.Quit)))
+
+	return b.String()
+}
+
+// renderItems writes one line per item; offset is the cursor index of the first item
+func (m *HomeModel) renderItems(b *strings.Builder, items []menuItem, offset, contentWidth int) {
+	for i, item := range items {
 		cursor := "  "
 		itemStyle := lipgloss.NewStyle().Foreground(styles.Foreground)
 		descStyle := lipgloss.NewStyle().Foreground(styles.Muted)
 
-		if i == m.cursor {
+		if offset+i == m.cursor {
 			cursor = styles.Highlight.Render("> ")
 			itemStyle = itemStyle.Bold(true).Foreground(styles.Primary)
 		}
 
-		// Calculate remaining width for description
-		titleLen := len(item.title) + 5 // cursor (2) + title + " - " (3)
-		descWidth := contentWidth - titleLen
-		if descWidth < 10 {
-			descWidth = 10
+		line := cursor + itemStyle.Render(item.title)
+		if item.description != "" {
+			line += " " + descStyle.Render("- "+item.description)
 		}
 
-		line := cursor + itemStyle.Render(item.title) + " " + descStyle.Render("- "+item.description)
-
 		// Truncate line if it exceeds content width
 		lineStyle := lipgloss.NewStyle().MaxWidth(contentWidth)
 		b.WriteString(lineStyle.Render(line))
 		b.WriteString("\n")
 	}
-
-	// Help
-	b.WriteString("\n")
-	helpStyle := lipgloss.NewStyle().Foreground(styles.Muted).MaxWidth(contentWidth)
-	b.WriteString(helpStyle.Render("↑/↓ navigate • enter select • q quit"))
-
-	return b.String()
 }
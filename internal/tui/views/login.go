@@ -1,9 +1,9 @@
 package views
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os/exec"
-	"runtime"
 	"strings"
 	"time"
 
@@ -13,7 +13,9 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/reflective-technologies/kiosk-cli/internal/auth"
 	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	kioskexec "github.com/reflective-technologies/kiosk-cli/internal/exec"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/components"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
 )
 
@@ -28,6 +30,12 @@ const (
 	LoginStateError
 )
 
+// loginCopyKey copies the user code to the clipboard again
+var loginCopyKey = tui.ViewKey("login", key.NewBinding(
+	key.WithKeys("c"),
+	key.WithHelp("c", "copy code"),
+))
+
 // LoginModel is the model for the login view
 type LoginModel struct {
 	width           int
@@ -38,16 +46,19 @@ type LoginModel struct {
 	userCode        string
 	verificationURI string
 	deviceCode      string
-	interval        int
+	interval        int       // seconds between polls
+	slowedDown      bool      // the server asked us to poll less often
+	expiresAt       time.Time // when the device code expires (zero if unknown)
+	deadline        time.Time // when polling gives up
+	cancelPoll      context.CancelFunc
+	copied          bool // user code is on the clipboard
 	error           error
 	user            *auth.UserInfo
 }
 
 // NewLoginModel creates a new login model
-func NewLoginModel() LoginModel {
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(styles.Primary)
+func NewLoginModel(spinnerStyle spinner.Spinner) LoginModel {
+	s := components.NewSpinnerModel(spinnerStyle)
 
 	return LoginModel{
 		keys:    tui.DefaultKeyMap(),
@@ -87,19 +98,48 @@ func (m *LoginModel) requestDeviceCode() tea.Msg {
 		UserCode:        deviceCode.UserCode,
 		VerificationURI: deviceCode.VerificationURI,
 		Interval:        deviceCode.Interval,
+		ExpiresIn:       deviceCode.ExpiresIn,
 	}
 }
 
-// pollForAuth is a command that polls for auth completion
-func (m *LoginModel) pollForAuth() tea.Cmd {
+// loginSlowDownMsg reports a new polling interval after the server asked
+// the login poll to slow down
+type loginSlowDownMsg struct {
+	interval time.Duration
+	ch       <-chan time.Duration
+}
+
+// waitForSlowDown waits for the next interval change from a running poll
+func waitForSlowDown(ch <-chan time.Duration) tea.Cmd {
 	return func() tea.Msg {
+		interval, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return loginSlowDownMsg{interval: interval, ch: ch}
+	}
+}
+
+// pollForAuth is a command that polls for auth completion.
+// It stops as soon as the view is left via cancelPoll.
+func (m *LoginModel) pollForAuth() tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelPoll = cancel
+	deviceCode, interval := m.deviceCode, m.interval
+	slowDowns := make(chan time.Duration, 1)
+
+	poll := func() tea.Msg {
+		defer cancel()
+		defer close(slowDowns)
+
 		cfg, err := config.Load()
 		if err != nil {
 			return tui.LoginCompleteMsg{Err: err}
 		}
 
 		flow := auth.NewDeviceFlow(cfg.APIUrl)
-		authResp, err := flow.PollForAuth(m.deviceCode, m.interval, auth.DefaultPollTimeout)
+		flow.OnSlowDown = auth.SendSlowDowns(slowDowns)
+		authResp, err := flow.PollForAuth(ctx, deviceCode, interval, auth.DefaultPollTimeout)
 		if err != nil {
 			return tui.LoginCompleteMsg{Err: err}
 		}
@@ -128,6 +168,7 @@ func (m *LoginModel) pollForAuth() tea.Cmd {
 
 		return tui.LoginCompleteMsg{User: creds.User}
 	}
+	return tea.Batch(poll, waitForSlowDown(slowDowns))
 }
 
 // Update handles messages for the login view
@@ -138,6 +179,9 @@ func (m *LoginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, m.keys.Back):
+			if m.cancelPoll != nil {
+				m.cancelPoll()
+			}
 			return m, func() tea.Msg { return tui.GoBackMsg{} }
 		case key.Matches(msg, m.keys.Enter):
 			if m.state == LoginStateSuccess || m.state == LoginStateError {
@@ -145,7 +189,11 @@ func (m *LoginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			// If waiting for auth, try to open browser again
 			if m.state == LoginStateWaitingForAuth && m.verificationURI != "" {
-				openBrowser(m.verificationURI)
+				kioskexec.OpenBrowser(m.verificationURI)
+			}
+		case key.Matches(msg, loginCopyKey):
+			if m.state == LoginStateWaitingForAuth && m.userCode != "" {
+				m.copied = kioskexec.CopyToClipboard(m.userCode) == nil
 			}
 		}
 
@@ -163,14 +211,29 @@ func (m *LoginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.interval < 5 {
 			m.interval = 5 // Minimum interval per RFC 8628
 		}
+		m.slowedDown = false
+		m.deadline = time.Now().Add(auth.DefaultPollTimeout)
+		m.expiresAt = time.Time{}
+		if msg.ExpiresIn > 0 {
+			m.expiresAt = time.Now().Add(time.Duration(msg.ExpiresIn) * time.Second)
+		}
 
-		// Try to open browser
-		openBrowser(m.verificationURI)
+		// Copy the code and open the browser, matching 'kiosk login'
+		m.copied = kioskexec.CopyToClipboard(m.userCode) == nil
+		kioskexec.OpenBrowser(m.verificationURI)
 
 		// Start polling for auth completion
 		cmds = append(cmds, m.pollForAuth())
 
+	case loginSlowDownMsg:
+		m.interval = int(msg.interval / time.Second)
+		m.slowedDown = true
+		cmds = append(cmds, waitForSlowDown(msg.ch))
+
 	case tui.LoginCompleteMsg:
+		if errors.Is(msg.Err, context.Canceled) {
+			break
+		}
 		if msg.Err != nil {
 			m.state = LoginStateError
 			m.error = msg.Err
@@ -187,6 +250,25 @@ func (m *LoginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// Leave stops polling for a login that's still pending
+func (m *LoginModel) Leave() {
+	if m.cancelPoll != nil {
+		m.cancelPoll()
+	}
+}
+
+// KeyHelp returns the keys shown in the help overlay
+func (m *LoginModel) KeyHelp() []key.Binding {
+	if m.state == LoginStateWaitingForAuth {
+		return []key.Binding{
+			tui.WithHelp(m.keys.Enter, "open browser"),
+			loginCopyKey,
+			tui.WithHelp(m.keys.Back, "cancel"),
+		}
+	}
+	return []key.Binding{tui.WithHelp(m.keys.Enter, "continue"), m.keys.Back}
+}
+
 // View renders the login view
 func (m *LoginModel) View() string {
 	var b strings.Builder
@@ -214,9 +296,9 @@ func (m *LoginModel) View() string {
 	b.WriteString("\n\n")
 	helpStyle := styles.HelpStyle
 	if m.state == LoginStateSuccess || m.state == LoginStateError {
-		b.WriteString(helpStyle.Render("Press enter or esc to continue"))
+		b.WriteString(helpStyle.Render("Press " + m.keys.Enter.Help().Key + " or " + m.keys.Back.Help().Key + " to continue"))
 	} else {
-		b.WriteString(helpStyle.Render("Press esc to cancel"))
+		b.WriteString(helpStyle.Render("Press " + m.keys.Back.Help().Key + " to cancel"))
 	}
 
 	return b.String()
@@ -244,14 +326,28 @@ func (m LoginModel) waitingView() string {
 	b.WriteString(boxStyle.Render(instructions.String()))
 	b.WriteString("\n\n")
 
+	if m.copied {
+		b.WriteString(styles.SuccessStyle.Render("✓"))
+		b.WriteString(" Code copied to clipboard")
+		b.WriteString("\n\n")
+	}
+
 	// Status
 	b.WriteString(m.spinner.View())
 	b.WriteString(" ")
 	b.WriteString(styles.MutedStyle.Render("Waiting for authorization..."))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	b.WriteString(styles.MutedStyle.Render(auth.Countdown(time.Now(), m.expiresAt, m.deadline)))
+	b.WriteString("\n")
+	if m.slowedDown {
+		b.WriteString(styles.MutedStyle.Render(fmt.Sprintf("Server asked us to slow down; checking every %ds", m.interval)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	// Hint
-	b.WriteString(styles.MutedStyle.Render("(Press enter to open browser again)"))
+	b.WriteString(styles.MutedStyle.Render(fmt.Sprintf("(Press %s to open browser again, %s to copy the code)",
+		m.keys.Enter.Help().Key, loginCopyKey.Help().Key)))
 
 	return b.String()
 }
@@ -294,21 +390,3 @@ func (m LoginModel) errorView() string {
 
 	return b.String()
 }
-
-// openBrowser opens the specified URL in the default browser
-func openBrowser(url string) error {
-	var cmd *exec.Cmd
-
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("open", url)
-	case "linux":
-		cmd = exec.Command("xdg-open", url)
-	case "windows":
-		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
-	default:
-		return fmt.Errorf("unsupported platform")
-	}
-
-	return cmd.Start()
-}
@@ -0,0 +1,123 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
+)
+
+// OnboardingModel is the welcome view shown on the first launch
+type OnboardingModel struct {
+	width  int
+	height int
+	cursor int
+	items  []menuItem
+	keys   tui.KeyMap
+}
+
+// NewOnboardingModel creates a new onboarding model
+func NewOnboardingModel() OnboardingModel {
+	return OnboardingModel{
+		keys: tui.DefaultKeyMap(),
+		items: []menuItem{
+			{
+				title:       "Log in",
+				description: "Sign in with GitHub to publish and manage apps",
+				action:      func() tea.Msg { return tui.NavigateMsg{View: tui.ViewLogin} },
+			},
+			{
+				title:       "Browse Apps",
+				description: "Find your first app to install",
+				action:      func() tea.Msg { return tui.NavigateMsg{View: tui.ViewBrowse} },
+			},
+			{
+				title:       "Skip",
+				description: "Go to the main menu",
+				action:      func() tea.Msg { return tui.NavigateMsg{View: tui.ViewHome} },
+			},
+		},
+	}
+}
+
+// SetSize updates the view dimensions
+func (m *OnboardingModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Init initializes the onboarding model
+func (m *OnboardingModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the onboarding view
+func (m *OnboardingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, m.keys.Down):
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, m.keys.Enter):
+			return m, m.items[m.cursor].action
+		case key.Matches(msg, m.keys.Back):
+			return m, func() tea.Msg { return tui.NavigateMsg{View: tui.ViewHome} }
+		}
+	}
+
+	return m, nil
+}
+
+// KeyHelp returns the keys shown in the help overlay
+func (m *OnboardingModel) KeyHelp() []key.Binding {
+	return []key.Binding{m.keys.Up, m.keys.Down, tui.WithHelp(m.keys.Enter, "select"), tui.WithHelp(m.keys.Back, "skip")}
+}
+
+// View renders the onboarding view
+func (m *OnboardingModel) View() string {
+	var b strings.Builder
+
+	contentWidth := m.width
+	if contentWidth <= 0 {
+		contentWidth = 80
+	}
+
+	if contentWidth >= 90 {
+		b.WriteString(styles.LogoStyled())
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(styles.Title.Render("Welcome to Kiosk"))
+	b.WriteString("\n\n")
+
+	textStyle := lipgloss.NewStyle().Foreground(styles.Foreground).Width(min(contentWidth, 72))
+	b.WriteString(textStyle.Render("Kiosk is an app store for Claude Code apps. Installing an app clones its repository and has Claude set it up for you; running it opens a Claude session in the app's directory."))
+	b.WriteString("\n\n")
+	b.WriteString(textStyle.Render("You can browse and install apps without an account. Log in with GitHub to publish your own."))
+	b.WriteString("\n\n")
+
+	for i, item := range m.items {
+		cursor := "  "
+		itemStyle := lipgloss.NewStyle().Foreground(styles.Foreground)
+		if i == m.cursor {
+			cursor = styles.Highlight.Render("> ")
+			itemStyle = itemStyle.Bold(true).Foreground(styles.Primary)
+		}
+		line := cursor + itemStyle.Render(item.title) + " " + styles.MutedStyle.Render("- "+item.description)
+		b.WriteString(lipgloss.NewStyle().MaxWidth(contentWidth).Render(line))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.HelpStyle.Render(tui.HelpLine(tui.Combined("navigate", m.keys.Up, m.keys.Down), m.keys.Enter, tui.WithHelp(m.keys.Back, "skip"), m.keys.Quit)))
+
+	return b.String()
+}
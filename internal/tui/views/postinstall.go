@@ -3,13 +3,18 @@ package views
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/reflective-technologies/kiosk-cli/internal/config"
+	kioskexec "github.com/reflective-technologies/kiosk-cli/internal/exec"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/components"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
 )
 
@@ -49,16 +54,41 @@ type PostInstallModel struct {
 	cloneProgress float64
 	cloneMessage  string
 
+	// When the install step began, for the elapsed time note
+	installStart time.Time
+
+	// Output of the clone and install steps, streamed from InstallStartedMsg
+	// into a scrollable viewport
+	events     <-chan tea.Msg
+	installLog []string
+	logView    viewport.Model
+	logReady   bool
+
 	// Post-install options
-	cursor  int
-	options []PostInstallOption
+	cursor    int
+	options   []PostInstallOption
+	optionErr error // last option failure, shown in the ready view
+}
+
+// maxInstallLogLines caps how much install output is kept for the viewport
+const maxInstallLogLines = 1000
+
+// installErrorLogLines is how many trailing log lines the error view shows
+const installErrorLogLines = 10
+
+// installEventMsg wraps a message read from an install's event channel
+type installEventMsg struct {
+	msg tea.Msg
+}
+
+// postInstallOptionDoneMsg is sent when an option that runs an external program finishes
+type postInstallOptionDoneMsg struct {
+	err error
 }
 
 // NewPostInstallModel creates a new post-install model
-func NewPostInstallModel(appName, appKey, appPath string) PostInstallModel {
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(styles.Primary)
+func NewPostInstallModel(appName, appKey, appPath string, spinnerStyle spinner.Spinner) PostInstallModel {
+	s := components.NewSpinnerModel(spinnerStyle)
 
 	p := progress.New(
 		progress.WithDefaultGradient(),
@@ -114,6 +144,19 @@ func NewPostInstallModel(appName, appKey, appPath string) PostInstallModel {
 func (m *PostInstallModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
+
+	// Title, step indicator, and status line above; help below
+	logHeight := max(height-10, 3)
+	if !m.logReady {
+		// Output may have arrived before the first size
+		m.logView = viewport.New(width, logHeight)
+		m.logView.SetContent(strings.Join(m.installLog, "\n"))
+		m.logView.GotoBottom()
+		m.logReady = true
+	} else {
+		m.logView.Width = width
+		m.logView.Height = logHeight
+	}
 }
 
 // Init initializes the post-install model
@@ -145,6 +188,10 @@ func (m *PostInstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, m.keys.Back):
 				return m, func() tea.Msg { return tui.GoBackMsg{} }
 			}
+		case PostInstallStateCloning, PostInstallStateInstalling:
+			var cmd tea.Cmd
+			m.logView, cmd = m.logView.Update(msg)
+			return m, cmd
 		case PostInstallStateError:
 			if key.Matches(msg, m.keys.Back) || key.Matches(msg, m.keys.Enter) {
 				return m, func() tea.Msg { return tui.GoBackMsg{} }
@@ -161,6 +208,20 @@ func (m *PostInstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.progress = progressModel.(progress.Model)
 		cmds = append(cmds, cmd)
 
+	case tui.InstallStartedMsg:
+		m.state = PostInstallStateCloning
+		m.events = msg.Events
+		m.installLog = nil
+		m.logView.SetContent("")
+		cmds = append(cmds, m.waitForInstallEvent())
+
+	case installEventMsg:
+		_, cmd := m.Update(msg.msg)
+		return m, tea.Batch(cmd, m.waitForInstallEvent())
+
+	case tui.InstallLogMsg:
+		m.appendInstallLog(msg.Line)
+
 	case tui.CloneProgressMsg:
 		m.cloneProgress = float64(msg.Percent) / 100.0
 		m.cloneMessage = msg.Message
@@ -171,6 +232,7 @@ func (m *PostInstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.error = msg.Err
 		} else {
 			m.state = PostInstallStateInstalling
+			m.installStart = time.Now()
 		}
 
 	case tui.AppInstalledMsg:
@@ -188,17 +250,85 @@ func (m *PostInstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tui.SuccessMsg:
 		// Option executed successfully, return to ready state
 		m.state = PostInstallStateReady
+
+	case postInstallOptionDoneMsg:
+		m.state = PostInstallStateReady
+		m.optionErr = msg.err
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
+// waitForInstallEvent waits for the next message from the running install
+func (m *PostInstallModel) waitForInstallEvent() tea.Cmd {
+	events := m.events
+	if events == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		msg, ok := <-events
+		if !ok {
+			return nil
+		}
+		return installEventMsg{msg: msg}
+	}
+}
+
+// appendInstallLog adds a line to the install log, keeping the viewport
+// pinned to the bottom unless the user has scrolled up
+func (m *PostInstallModel) appendInstallLog(line string) {
+	follow := m.logView.AtBottom()
+	m.installLog = append(m.installLog, line)
+	if len(m.installLog) > maxInstallLogLines {
+		m.installLog = m.installLog[len(m.installLog)-maxInstallLogLines:]
+	}
+	m.logView.SetContent(strings.Join(m.installLog, "\n"))
+	if follow {
+		m.logView.GotoBottom()
+	}
+}
+
 func (m *PostInstallModel) executeOption(opt PostInstallOption) tea.Cmd {
+	m.optionErr = nil
+	if opt.Command == "edit" {
+		return m.openEditor()
+	}
 	return func() tea.Msg {
 		return tui.SuccessMsg{Message: fmt.Sprintf("Executing: %s", opt.Command)}
 	}
 }
 
+// openEditor opens the app directory in the user's editor.
+// Terminal editors take over the screen until they exit; GUI editors are started and left running.
+func (m *PostInstallModel) openEditor() tea.Cmd {
+	configured := ""
+	if cfg, err := config.Load(); err == nil {
+		configured = cfg.Editor
+	}
+
+	cmd, terminal, err := kioskexec.EditorCmd(m.appPath, "", configured)
+	if err != nil {
+		return func() tea.Msg { return postInstallOptionDoneMsg{err: err} }
+	}
+
+	if terminal {
+		return tea.ExecProcess(cmd, func(err error) tea.Msg {
+			if err != nil {
+				return postInstallOptionDoneMsg{err: fmt.Errorf("editor exited: %w", err)}
+			}
+			return postInstallOptionDoneMsg{}
+		})
+	}
+
+	return func() tea.Msg {
+		if err := cmd.Start(); err != nil {
+			return postInstallOptionDoneMsg{err: fmt.Errorf("failed to open editor: %w", err)}
+		}
+		go cmd.Wait() // reap the process once the editor exits
+		return postInstallOptionDoneMsg{}
+	}
+}
+
 // SetState allows external code to set the state
 func (m *PostInstallModel) SetState(state PostInstallState) {
 	m.state = state
@@ -210,6 +340,44 @@ func (m *PostInstallModel) SetCloneProgress(percent int, message string) {
 	m.cloneMessage = message
 }
 
+// Busy reports whether the install or a post-install option is running
+func (m *PostInstallModel) Busy() bool {
+	switch m.state {
+	case PostInstallStateCloning, PostInstallStateInstalling, PostInstallStateRunning:
+		return true
+	}
+	return false
+}
+
+// KeyHelp returns the keys shown in the help overlay
+func (m *PostInstallModel) KeyHelp() []key.Binding {
+	switch m.state {
+	case PostInstallStateReady:
+		return []key.Binding{
+			m.keys.Up,
+			m.keys.Down,
+			tui.WithHelp(m.keys.Enter, "run option"),
+			m.keys.Back,
+		}
+	case PostInstallStateCloning, PostInstallStateInstalling:
+		if len(m.installLog) > 0 {
+			return m.logKeys()
+		}
+	case PostInstallStateError:
+		return []key.Binding{m.keys.Back}
+	}
+	return nil
+}
+
+// logKeys are the keys for scrolling the install log
+func (m *PostInstallModel) logKeys() []key.Binding {
+	return []key.Binding{
+		tui.WithHelp(m.logView.KeyMap.Up, "scroll up"),
+		tui.WithHelp(m.logView.KeyMap.Down, "scroll down"),
+		m.logView.KeyMap.PageDown,
+	}
+}
+
 // View renders the post-install view
 func (m *PostInstallModel) View() string {
 	var b strings.Builder
@@ -259,6 +427,12 @@ func (m PostInstallModel) cloningView() string {
 	b.WriteString(" Cloning repository...")
 	b.WriteString("\n\n")
 
+	// git's own output, when the clone streams it, replaces the progress bar
+	if len(m.installLog) > 0 {
+		b.WriteString(m.logSection())
+		return b.String()
+	}
+
 	// Progress bar
 	b.WriteString(m.progress.ViewAs(m.cloneProgress))
 	b.WriteString("\n")
@@ -281,9 +455,29 @@ func (m PostInstallModel) installingView() string {
 	// Progress
 	b.WriteString(m.spinner.View())
 	b.WriteString(" Installing dependencies...")
+	if note := components.ElapsedNote(time.Since(m.installStart)); note != "" {
+		b.WriteString(" ")
+		b.WriteString(styles.MutedStyle.Render(note))
+	}
 	b.WriteString("\n\n")
 
-	b.WriteString(styles.MutedStyle.Render("Claude is setting up your environment..."))
+	if len(m.installLog) == 0 {
+		b.WriteString(styles.MutedStyle.Render("Claude is setting up your environment..."))
+		return b.String()
+	}
+	b.WriteString(m.logSection())
+
+	return b.String()
+}
+
+// logSection renders the install log viewport and its scroll position
+func (m PostInstallModel) logSection() string {
+	var b strings.Builder
+
+	b.WriteString(m.logView.View())
+	b.WriteString("\n\n")
+	scrollPercent := int(m.logView.ScrollPercent() * 100)
+	b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("%s • %d%%", tui.HelpLine(m.logKeys()...), scrollPercent)))
 
 	return b.String()
 }
@@ -310,6 +504,11 @@ func (m PostInstallModel) readyView() string {
 	b.WriteString(divider)
 	b.WriteString("\n\n")
 
+	if m.optionErr != nil {
+		b.WriteString(styles.ErrorStyle.Render("✗ " + m.optionErr.Error()))
+		b.WriteString("\n\n")
+	}
+
 	// Question
 	questionStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Foreground)
 	b.WriteString(questionStyle.Render("What would you like to do next?"))
@@ -338,7 +537,11 @@ func (m PostInstallModel) readyView() string {
 
 	// Help
 	b.WriteString("\n")
-	b.WriteString(styles.HelpStyle.Render("↑/↓ navigate • enter select • esc go back"))
+	b.WriteString(styles.HelpStyle.Render(tui.HelpLine(
+		tui.Combined("navigate", m.keys.Up, m.keys.Down),
+		m.keys.Enter,
+		tui.WithHelp(m.keys.Back, "go back"),
+	)))
 
 	return b.String()
 }
@@ -367,8 +570,19 @@ func (m PostInstallModel) errorView() string {
 		b.WriteString(styles.MutedStyle.Render(m.error.Error()))
 	}
 
+	// The end of the install output usually says what went wrong
+	if len(m.installLog) > 0 {
+		tail := m.installLog[max(len(m.installLog)-installErrorLogLines, 0):]
+		b.WriteString("\n\n")
+		for _, line := range tail {
+			b.WriteString("  ")
+			b.WriteString(styles.MutedStyle.Render(line))
+			b.WriteString("\n")
+		}
+	}
+
 	b.WriteString("\n\n")
-	b.WriteString(styles.HelpStyle.Render("Press enter or esc to go back"))
+	b.WriteString(styles.HelpStyle.Render("Press " + m.keys.Enter.Help().Key + " or " + m.keys.Back.Help().Key + " to go back"))
 
 	return b.String()
 }
@@ -10,7 +10,9 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/reflective-technologies/kiosk-cli/internal/kioskmd"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui"
+	"github.com/reflective-technologies/kiosk-cli/internal/tui/components"
 	"github.com/reflective-technologies/kiosk-cli/internal/tui/styles"
 )
 
@@ -57,10 +59,8 @@ type PublishModel struct {
 }
 
 // NewPublishModel creates a new publish model
-func NewPublishModel() PublishModel {
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(styles.Primary)
+func NewPublishModel(spinnerStyle spinner.Spinner) PublishModel {
+	s := components.NewSpinnerModel(spinnerStyle)
 
 	return PublishModel{
 		keys:    tui.DefaultKeyMap(),
@@ -141,11 +141,7 @@ func isUnpublishableDirectory(dir string) bool {
 
 // checkIfPublishable checks if a directory can be published
 func checkIfPublishable(dir string) (hasKioskMd, hasGit bool) {
-	// Check for KIOSK.md
-	kioskPath := filepath.Join(dir, "KIOSK.md")
-	if _, err := os.Stat(kioskPath); err == nil {
-		hasKioskMd = true
-	}
+	hasKioskMd = kioskmd.Exists(dir)
 
 	// Check for .git directory
 	gitPath := filepath.Join(dir, ".git")
@@ -227,11 +223,11 @@ func (m *PublishModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					return m, func() tea.Msg { return tui.GoBackMsg{} }
 				}
-			case key.Matches(msg, m.keys.Up), msg.String() == "left":
+			case key.Matches(msg, m.keys.Up, m.keys.Left):
 				if m.confirmCursor > 0 {
 					m.confirmCursor--
 				}
-			case key.Matches(msg, m.keys.Down), msg.String() == "right":
+			case key.Matches(msg, m.keys.Down, m.keys.Right):
 				if m.confirmCursor < 1 {
 					m.confirmCursor++
 				}
@@ -356,6 +352,32 @@ func (m *PublishModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// Busy reports whether the directory is still being checked
+func (m *PublishModel) Busy() bool {
+	return m.state == PublishStateChecking
+}
+
+// KeyHelp returns the keys shown in the help overlay
+func (m *PublishModel) KeyHelp() []key.Binding {
+	switch m.state {
+	case PublishStatePickDirectory:
+		return []key.Binding{
+			m.keys.Up,
+			m.keys.Down,
+			tui.WithHelp(m.keys.Enter, "open directory"),
+			tui.WithHelp(m.keys.Back, "previous directory"),
+		}
+	case PublishStatePublishable:
+		return []key.Binding{
+			m.keys.Left,
+			m.keys.Right,
+			tui.WithHelp(m.keys.Enter, "confirm"),
+			m.keys.Back,
+		}
+	}
+	return []key.Binding{m.keys.Back}
+}
+
 // View renders the publish view
 func (m *PublishModel) View() string {
 	switch m.state {
@@ -463,12 +485,11 @@ func (m *PublishModel) publishableView() string {
 	b.WriteString(noStyle.Render("No"))
 	b.WriteString("\n\n")
 
-	// Help - show "go back" if we have history, "cancel" if at start
-	if len(m.dirHistory) > 0 {
-		b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render("←/→ select • enter confirm • esc go back"))
-	} else {
-		b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render("←/→ select • enter confirm • esc cancel"))
-	}
+	b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render(tui.HelpLine(
+		tui.Combined("select", m.keys.Left, m.keys.Right),
+		tui.WithHelp(m.keys.Enter, "confirm"),
+		m.dirBackHelp(),
+	)))
 
 	return b.String()
 }
@@ -541,21 +562,29 @@ func (m *PublishModel) pickDirectoryView() string {
 	if len(m.directories) > visibleItems {
 		b.WriteString("\n")
 		b.WriteString(styles.MutedStyle.Render(
-			"  ... and more (scroll with ↑/↓)"))
+			"  ... and more (scroll with " + tui.Combined("", m.keys.Up, m.keys.Down).Help().Key + ")"))
 	}
 
 	b.WriteString("\n\n")
 
-	// Help - show "go back" if we have history, "cancel" if at start
-	if len(m.dirHistory) > 0 {
-		b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render("↑/↓ navigate • enter select • esc go back"))
-	} else {
-		b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render("↑/↓ navigate • enter select • esc cancel"))
-	}
+	b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render(tui.HelpLine(
+		tui.Combined("navigate", m.keys.Up, m.keys.Down),
+		m.keys.Enter,
+		m.dirBackHelp(),
+	)))
 
 	return b.String()
 }
 
+// dirBackHelp describes the back key while choosing a directory: "go back"
+// if there's history, "cancel" if at the start
+func (m *PublishModel) dirBackHelp() key.Binding {
+	if len(m.dirHistory) > 0 {
+		return tui.WithHelp(m.keys.Back, "go back")
+	}
+	return tui.WithHelp(m.keys.Back, "cancel")
+}
+
 func (m *PublishModel) notPublishableView() string {
 	var b strings.Builder
 
@@ -592,7 +621,8 @@ func (m *PublishModel) notPublishableView() string {
 	b.WriteString("\n\n")
 
 	// Help
-	b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render("enter or esc to go back"))
+	b.WriteString(styles.HelpStyle.Copy().MaxWidth(contentWidth).Render(
+		m.keys.Enter.Help().Key + " or " + m.keys.Back.Help().Key + " to go back"))
 
 	return b.String()
 }